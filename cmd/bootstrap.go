@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var bootstrapCmd = &cobra.Command{
+	Use:   "bootstrap <owner/repo>",
+	Short: "Sync the configured labels, milestones and issues onto a repository",
+	Long: "Create or update the labels and milestones declared in repo_settings and open its issues on an " +
+		"existing repository. Running it again changes nothing once the repository matches.",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runBootstrap,
+}
+
+func init() {
+	rootCmd.AddCommand(bootstrapCmd)
+}
+
+func runBootstrap(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	runner := NewWizardRunner()
+	cfg, _ := loadConfig()
+
+	if err := runner.bootstrapRepository(ctx, args[0], cfg.RepoSettings); err != nil {
+		return runner.handleError(err)
+	}
+
+	fmt.Printf("✅ Labels, milestones and issues of %s are up to date\n", args[0])
+	return nil
+}
+
+// bootstrapRepository syncs the labels, milestones and issues of the settings onto the repository.
+// Other settings are left out: they are only applied when a repository is created.
+func (wr *WizardRunner) bootstrapRepository(ctx context.Context, fullName string, settings *models.RepositorySettings) error {
+	if owner, name, ok := strings.Cut(fullName, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return models.NewValidationError(fmt.Sprintf("Invalid repository '%s': use owner/repo", fullName))
+	}
+	if !settings.HasBootstrap() {
+		return models.NewValidationError("No labels, milestones or issues are configured in repo_settings")
+	}
+
+	bootstrap := &models.RepositorySettings{
+		Labels:              settings.Labels,
+		RemoveDefaultLabels: settings.RemoveDefaultLabels,
+		Milestones:          settings.Milestones,
+		Issues:              settings.Issues,
+	}
+	return wr.githubClient.ApplyRepositorySettings(ctx, fullName, bootstrap)
+}
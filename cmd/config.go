@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/config"
 	"github.com/spf13/cobra"
@@ -26,10 +27,70 @@ var configShowCmd = &cobra.Command{
 		fmt.Println("🔧 gh-wizard Configuration")
 		fmt.Println("========================")
 		fmt.Printf("Default Visibility: %s\n", map[bool]string{true: "Private", false: "Public"}[cfg.DefaultPrivate])
+		if cfg.DefaultOwner != "" {
+			fmt.Printf("Default Owner: %s\n", cfg.DefaultOwner)
+		}
 		fmt.Printf("Default Clone: %s\n", map[bool]string{true: "Enabled", false: "Disabled"}[cfg.DefaultClone])
 		fmt.Printf("Auto Add README: %s\n", map[bool]string{true: "Enabled", false: "Disabled"}[cfg.DefaultAddRemote])
 		fmt.Printf("Cache Timeout: %d minutes\n", cfg.CacheTimeout)
+		fmt.Printf("Max Repositories: %d per owner\n", cfg.MaxRepositories)
 		fmt.Printf("Theme: %s\n", cfg.Theme)
+		if cfg.TemplateSort != "" {
+			fmt.Printf("Template Sort: %s\n", cfg.TemplateSort)
+		}
+		if cfg.GitBackend != "" {
+			fmt.Printf("Git Backend: %s\n", cfg.GitBackend)
+		}
+
+		if cfg.Profile != "" {
+			fmt.Printf("Profile: %s\n", cfg.Profile)
+		}
+
+		if len(cfg.Profiles) > 0 {
+			fmt.Println("\nProfiles")
+			for _, name := range sortedProfileNames(cfg.Profiles) {
+				profile := cfg.Profiles[name]
+				fmt.Printf("  - %s: topics=%v language=%s\n", name, profile.Topics, profile.Language)
+			}
+		}
+
+		if len(cfg.Organizations) > 0 {
+			fmt.Println("\nOrganizations")
+			for _, owner := range cfg.Organizations {
+				fmt.Printf("  - %s\n", owner)
+			}
+		}
+
+		if len(cfg.LocalTemplates) > 0 {
+			fmt.Println("\nLocal Templates")
+			for _, path := range cfg.LocalTemplates {
+				fmt.Printf("  - %s\n", path)
+			}
+		}
+
+		if len(cfg.Registries) > 0 {
+			fmt.Println("\nRegistries")
+			for _, registry := range cfg.Registries {
+				fmt.Printf("  - %s\n", registry)
+			}
+		}
+
+		if !cfg.Repository.IsEmpty() {
+			fmt.Println("\nRepository Options")
+			if cfg.Repository.Visibility != "" {
+				fmt.Printf("  - Visibility: %s\n", cfg.Repository.Visibility)
+			}
+			for _, line := range cfg.Repository.GetDisplaySummary() {
+				fmt.Printf("  - %s\n", line)
+			}
+		}
+
+		if settings := cfg.RepoSettings.GetDisplaySummary(); len(settings) > 0 {
+			fmt.Println("\nRepository Settings")
+			for _, line := range settings {
+				fmt.Printf("  - %s\n", line)
+			}
+		}
 
 		if len(cfg.RecentTemplates) > 0 {
 			fmt.Println("\nRecent Templates")
@@ -59,6 +120,16 @@ var configInitCmd = &cobra.Command{
 	},
 }
 
+// sortedProfileNames returns the profile names in a stable order
+func sortedProfileNames(profiles map[string]config.Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configInitCmd)
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/wizard"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffRefFlag  string
+	diffJSONFlag bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [path]",
+	Short: "Show how a generated project differs from its template",
+	Long: "Render the template a project was generated from with the recorded answers and compare it " +
+		"with the project. Exits with a non-zero status when the project has drifted.",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffRefFlag, "ref", "", "Template branch, tag or commit to compare with (default: the recorded ref or the template's default branch)")
+	diffCmd.Flags().BoolVar(&diffJSONFlag, "json", false, "Print a JSON summary of added, removed and modified files instead of a diff")
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	runner := NewWizardRunner()
+
+	projectDir := "."
+	if len(args) > 0 {
+		projectDir = args[0]
+	}
+
+	report, err := wizard.NewTemplateUpdater(projectDir).Diff(ctx, diffRefFlag)
+	if err != nil {
+		return runner.handleError(err)
+	}
+
+	if diffJSONFlag {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), report.Diff)
+	}
+
+	if report.HasDrift() {
+		return fmt.Errorf("project differs from template %s@%s in %d file(s)",
+			report.Template, wizard.ShortCommit(report.TemplateCommit),
+			len(report.Added)+len(report.Removed)+len(report.Modified))
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/config"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/wizard"
+)
+
+// hookTrustKey identifies the template whose hooks are trusted
+func hookTrustKey(projectConfig *models.ProjectConfig) string {
+	return projectConfig.Template.GetReference()
+}
+
+// confirmHookTrust decides whether the template's hooks may run.
+// Trust is asked once per template and remembered until the hook commands change.
+// --yes never grants trust: without --trust-hooks, untrusted hooks are skipped with a warning.
+func (wr *WizardRunner) confirmHookTrust(projectConfig *models.ProjectConfig) (bool, error) {
+	if noHooksFlag || projectConfig.Template == nil || projectConfig.Manifest == nil {
+		return false, nil
+	}
+
+	hooks := projectConfig.Manifest.Hooks.All()
+	if len(hooks) == 0 {
+		return false, nil
+	}
+
+	if trustHooksFlag {
+		return true, nil
+	}
+
+	key := hookTrustKey(projectConfig)
+	fingerprint := wizard.HookFingerprint(projectConfig.Manifest.Hooks)
+
+	cfg, loaded := loadConfig()
+	if cfg.IsHookTrusted(key, fingerprint) {
+		return true, nil
+	}
+
+	if yesFlag {
+		fmt.Printf("⚠️  Skipping hooks of template '%s': they have not been trusted. Run without --yes to review them, or pass --trust-hooks.\n", key)
+		return false, nil
+	}
+
+	fmt.Println()
+	fmt.Printf("🪝 Template '%s' wants to run these commands:\n", key)
+	for _, hook := range projectConfig.Manifest.Hooks.PreGenerate {
+		fmt.Printf("    $ %s  (pre_generate, in the template directory)\n", hook.Run)
+	}
+	for _, hook := range projectConfig.Manifest.Hooks.PostCreate {
+		fmt.Printf("    $ %s  (post_create, in the new project)\n", hook.Run)
+	}
+
+	trusted := false
+	prompt := &survey.Confirm{
+		Message: "Do you trust this template and want to run its hooks?",
+		Default: false,
+		Help:    "Your answer is remembered until the template changes its hooks. Use --no-hooks to skip them.",
+	}
+	if err := survey.AskOne(prompt, &trusted); err != nil {
+		return false, err
+	}
+
+	if trusted {
+		rememberHookTrust(cfg, loaded, key, fingerprint)
+	}
+
+	return trusted, nil
+}
+
+// rememberHookTrust saves the trust in the configuration file. A configuration that could not be loaded
+// is left alone: saving the defaults in its place would silently drop every setting the user has.
+func rememberHookTrust(cfg *config.Config, loaded bool, key, fingerprint string) {
+	if !loaded {
+		fmt.Println("⚠️  Not remembering hook trust: fix the configuration file so it can be saved")
+		return
+	}
+
+	cfg.TrustHooks(key, fingerprint)
+	if err := cfg.Save(); err != nil {
+		fmt.Printf("⚠️  Failed to remember hook trust: %v\n", err)
+	}
+}
+
+// runPreGenerateHooks runs the template's pre-generate hooks before the configuration is reviewed
+func (wr *WizardRunner) runPreGenerateHooks(ctx context.Context, projectConfig *models.ProjectConfig) error {
+	executor := wizard.NewProjectExecutor(wr.githubClient)
+	executor.SetHooksEnabled(wr.runHooks)
+	return executor.RunPreGenerateHooks(ctx, projectConfig)
+}
@@ -3,44 +3,94 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"os/signal"
-	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/config"
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/utils"
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/wizard"
 	"github.com/spf13/cobra"
 )
 
 var (
-	templateFlag  string
-	nameFlag      string
-	dryRunFlag    bool
-	yesFlag       bool
-	classicUIFlag bool
+	templateFlag   string
+	nameFlag       string
+	dryRunFlag     bool
+	yesFlag        bool
+	classicUIFlag  bool
+	varFlags       []string
+	noHooksFlag    bool
+	trustHooksFlag bool
+	templateOwners []string
+	ownerFlag      string
+	teamFlags      []string
+	repoTopicFlags []string
+	homepageFlag   string
+	visibilityFlag string
+	noIssuesFlag   bool
+	noProjectsFlag bool
+	noWikiFlag     bool
+	propertyFlags  []string
+	refreshFlag    bool
+	sortFlag       string
+	topicFlags     []string
+	languageFlag   string
+	profileFlag    string
+	searchFlag     string
+	refFlag        string
+	generateFlag   bool
+	allBranchFlag  bool
 )
 
+// backgroundWaitTimeout bounds how long the wizard waits for a background template cache refresh before exiting
+const backgroundWaitTimeout = 10 * time.Second
+
 var rootCmd = &cobra.Command{
 	Use:   "wizard",
 	Short: "🔮 GitHub Repository Wizard",
 	Long:  "Magically simple and intuitive GitHub repository creation wizard",
 	RunE:  runWizard,
+
+	PersistentPreRunE: configureGitBackend,
 }
 
 func init() {
 	// Flag definitions
-	rootCmd.Flags().StringVarP(&templateFlag, "template", "t", "", "Template to use (e.g. user/repo or 'none')")
+	rootCmd.Flags().StringVarP(&templateFlag, "template", "t", "", "Template to use (e.g. user/repo, user/repo//path/to/template, a git URL, ./path, file:///path or 'none')")
+	rootCmd.Flags().StringVar(&refFlag, "ref", "", "Template branch, tag or commit to use (default: the template's default branch)")
 	rootCmd.Flags().StringVarP(&nameFlag, "name", "n", "", "Project name (for non-interactive mode)")
 	rootCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show configuration only without actual creation")
 	rootCmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "Skip all confirmations")
 	rootCmd.Flags().BoolVar(&classicUIFlag, "classic-ui", false, "Use classic multi-question UI instead of create-next-app style")
+	rootCmd.Flags().StringArrayVar(&varFlags, "var", nil, "Template variable as key=value (repeatable, for non-interactive mode)")
+	rootCmd.Flags().BoolVar(&noHooksFlag, "no-hooks", false, "Do not run hooks declared by the template")
+	rootCmd.Flags().BoolVar(&trustHooksFlag, "trust-hooks", false, "Run the template's hooks without asking to trust them")
+	rootCmd.Flags().StringArrayVar(&templateOwners, "template-owner", nil, "Also list templates of this organization or org/team (repeatable)")
+	rootCmd.Flags().StringVar(&ownerFlag, "owner", "", "User or organization to create the GitHub repository under (default: default_owner or yourself)")
+	rootCmd.Flags().StringArrayVar(&teamFlags, "team", nil, "Give an organization team access to the repository as team or team:permission (repeatable)")
+	rootCmd.Flags().StringArrayVar(&repoTopicFlags, "repo-topic", nil, "Topic to tag the GitHub repository with (repeatable)")
+	rootCmd.Flags().StringVar(&homepageFlag, "homepage", "", "Homepage URL of the GitHub repository")
+	rootCmd.Flags().StringVar(&visibilityFlag, "visibility", "", "Visibility of the GitHub repository: public, private or internal")
+	rootCmd.Flags().BoolVar(&noIssuesFlag, "no-issues", false, "Disable issues on the GitHub repository")
+	rootCmd.Flags().BoolVar(&noProjectsFlag, "no-projects", false, "Disable projects on the GitHub repository")
+	rootCmd.Flags().BoolVar(&noWikiFlag, "no-wiki", false, "Disable the wiki of the GitHub repository")
+	rootCmd.Flags().StringArrayVar(&propertyFlags, "property", nil, "Organization custom property of the repository as name=value (repeatable)")
+	rootCmd.Flags().BoolVar(&refreshFlag, "refresh", false, "Ignore the cached template list and fetch it again")
+	rootCmd.Flags().StringVar(&sortFlag, "sort", "", "Order templates by stars, updated or name")
+	rootCmd.Flags().StringArrayVar(&topicFlags, "topic", nil, "Only list templates tagged with this topic (repeatable)")
+	rootCmd.Flags().StringVar(&languageFlag, "language", "", "Only list templates in this language")
+	rootCmd.Flags().StringVar(&profileFlag, "profile", "", "Configuration profile providing default filters")
+	rootCmd.Flags().StringVarP(&searchFlag, "search", "s", "", "Search public template repositories on GitHub instead of listing your own")
+	rootCmd.Flags().BoolVar(&generateFlag, "generate", false, "Create the GitHub repository from the template on GitHub, then clone it")
+	rootCmd.Flags().BoolVar(&allBranchFlag, "include-all-branches", false, "Copy every branch of the template (with --generate)")
 }
 
 func Execute() {
@@ -67,7 +117,20 @@ func runWizard(cmd *cobra.Command, args []string) error {
 		os.Exit(0)
 	}()
 
-	runner := NewWizardRunner()
+	cfg, _ := loadConfig()
+	options := discoveryOptions(cfg)
+	runner := NewWizardRunnerWithOptions(options)
+	// Let a background refresh of the template cache finish so the next run can use it
+	defer runner.githubClient.Wait(backgroundWaitTimeout)
+
+	sortOrder, err := templateSortOrder(cfg)
+	if err != nil {
+		return runner.handleError(err)
+	}
+	filter, err := templateFilter(cfg)
+	if err != nil {
+		return runner.handleError(err)
+	}
 
 	// Check prerequisites
 	if !dryRunFlag {
@@ -76,42 +139,61 @@ func runWizard(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Fetch user's template repositories
-	fmt.Println("🔍 Fetching your template repositories...")
-	templates, templateErr := runner.githubClient.SearchPopularTemplates(ctx)
-	if templateErr != nil {
-		// Continue without templates if fetching fails
-		fmt.Printf("⚠️  Failed to fetch templates: %v\n", templateErr)
-		fmt.Println("Continuing without templates.")
-		templates = []models.Template{}
-	} else if len(templates) == 0 {
-		fmt.Println("📭 No template repositories found")
-		fmt.Println("💡 Set repositories as 'Template repository' on GitHub to display them here.")
-	} else {
-		fmt.Printf("✅ Found %d template repositories\n", len(templates))
+	var templates []models.Template
+	if searchFlag != "" {
+		// Search public templates instead of listing your own
+		templates, err = runner.searchTemplates(ctx, searchFlag)
+		if err != nil {
+			return runner.handleError(err)
+		}
+	} else if !wizard.IsLocalTemplateRef(templateFlag) && !wizard.IsGitURL(templateFlag) {
+		// A local or git URL --template needs no discovery
+		templates = runner.listTemplates(ctx, options.Owners)
+		templates = append(templates, runner.localTemplates(cfg.LocalTemplates)...)
+		templates = runner.applyRegistries(ctx, cfg.Registries, templates)
 	}
 
 	var config *models.ProjectConfig
-	var err error
 
 	// Non-interactive mode or interactive mode
 	if nameFlag != "" || templateFlag != "" {
 		// Non-interactive mode
 		config, err = runner.runNonInteractiveMode(templates, templateFlag, nameFlag)
+		if err == nil {
+			err = runner.applyTemplateVariables(ctx, config, varFlags)
+		}
 	} else {
 		// Interactive mode
-		config, err = runner.runInteractiveMode(templates)
+		config, err = runner.runInteractiveMode(ctx, templates, sortOrder, filter, cfg.DefaultOwner)
+	}
+
+	if err == nil {
+		err = applyRepositoryFlags(config, cfg)
 	}
 
 	if err != nil {
 		return runner.handleError(err)
 	}
 
+	if !dryRunFlag {
+		// Hooks run only when the template is trusted
+		runner.runHooks, err = runner.confirmHookTrust(config)
+		if err != nil {
+			return runner.handleError(err)
+		}
+
+		// Pre-generate hooks may reject the answers or derive variables before anything is written
+		if err := runner.runPreGenerateHooks(ctx, config); err != nil {
+			return runner.handleError(err)
+		}
+	}
+
 	// Display configuration
 	runner.printConfiguration(config)
 
 	if dryRunFlag {
 		fmt.Println("🔍 Dry run mode: No actual creation will be performed")
+		printSettingsRequests(config)
 		return nil
 	}
 
@@ -131,28 +213,185 @@ func runWizard(cmd *cobra.Command, args []string) error {
 	if err := runner.createProject(ctx, config); err != nil {
 		return runner.handleError(err)
 	}
-
-	fmt.Println("✨ Project successfully created!")
 	return nil
 }
 
 // WizardRunner manages wizard execution
 type WizardRunner struct {
 	githubClient github.Client
+	runHooks     bool
 }
 
 // NewWizardRunner creates a new WizardRunner
 func NewWizardRunner() *WizardRunner {
+	return NewWizardRunnerWithOptions(github.ClientOptions{})
+}
+
+// NewWizardRunnerWithOptions creates a new WizardRunner whose client discovers templates with the given options
+func NewWizardRunnerWithOptions(options github.ClientOptions) *WizardRunner {
 	return &WizardRunner{
-		githubClient: github.NewClient(),
+		githubClient: github.NewClientWithOptions(options),
+	}
+}
+
+// configureGitBackend selects the git backend named in the configuration for every command
+func configureGitBackend(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		// Commands report an unreadable configuration themselves; keep the default backend
+		return nil
+	}
+
+	backend, err := utils.NewGitBackend(cfg.GitBackend)
+	if err != nil {
+		return err
+	}
+	utils.SetDefaultGitBackend(backend)
+	return nil
+}
+
+// loadConfig loads the configuration, falling back to the defaults when it is unreadable.
+// It reports whether the configuration was loaded; a fallback must never be saved over the file.
+func loadConfig() (*config.Config, bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("⚠️  Failed to load configuration: %v\n", err)
+		return config.GetDefault(), false
+	}
+	return cfg, true
+}
+
+// discoveryOptions returns template discovery options from configuration and flags
+func discoveryOptions(cfg *config.Config) github.ClientOptions {
+	return github.ClientOptions{
+		Owners:          mergeOwners(cfg.Organizations, templateOwners),
+		MaxRepositories: cfg.MaxRepositories,
+		CacheTimeout:    time.Duration(cfg.CacheTimeout) * time.Minute,
+		Refresh:         refreshFlag,
+	}
+}
+
+// templateSortOrder returns the template order from the --sort flag or the configuration
+func templateSortOrder(cfg *config.Config) (models.TemplateSortOrder, error) {
+	if sortFlag != "" {
+		return models.ParseTemplateSortOrder(sortFlag)
+	}
+	return models.ParseTemplateSortOrder(cfg.TemplateSort)
+}
+
+// templateFilter returns the template filter from the flags, falling back to the profile's defaults
+func templateFilter(cfg *config.Config) (models.TemplateFilter, error) {
+	profile, err := cfg.GetProfile(profileFlag)
+	if err != nil {
+		return models.TemplateFilter{}, models.NewValidationError(err.Error())
+	}
+
+	filter := models.TemplateFilter{Topics: profile.Topics, Language: profile.Language}
+	if len(topicFlags) > 0 {
+		filter.Topics = topicFlags
+	}
+	if languageFlag != "" {
+		filter.Language = languageFlag
+	}
+	return filter, nil
+}
+
+// mergeOwners combines owner lists, dropping blanks and duplicates
+func mergeOwners(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var owners []string
+	for _, list := range lists {
+		for _, owner := range list {
+			owner = strings.TrimSpace(owner)
+			key := strings.ToLower(owner)
+			if owner == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
+
+// listTemplates fetches the template repositories of the user and the given owners.
+// Failing to fetch them is not fatal; the project can be created without a template.
+func (wr *WizardRunner) listTemplates(ctx context.Context, owners []string) []models.Template {
+	if len(owners) > 0 {
+		fmt.Printf("🔍 Fetching template repositories (you, %s)...\n", strings.Join(owners, ", "))
+	} else {
+		fmt.Println("🔍 Fetching your template repositories...")
+	}
+
+	templates, err := wr.githubClient.SearchPopularTemplates(ctx)
+	if err != nil {
+		// Continue without templates if fetching fails
+		fmt.Printf("⚠️  Failed to fetch templates: %v\n", err)
+		fmt.Println("Continuing without templates.")
+		return []models.Template{}
+	}
+
+	if len(templates) == 0 {
+		fmt.Println("📭 No template repositories found")
+		fmt.Println("💡 Set repositories as 'Template repository' on GitHub to display them here.")
+	} else {
+		fmt.Printf("✅ Found %d template repositories\n", len(templates))
 	}
+	return templates
+}
+
+// localTemplates returns the configured local template directories (collections expanded), skipping unusable ones
+func (wr *WizardRunner) localTemplates(paths []string) []models.Template {
+	var templates []models.Template
+	for _, path := range paths {
+		local, err := wizard.LocalTemplates(path)
+		if err != nil {
+			fmt.Printf("⚠️  Skipping local template: %v\n", err)
+			continue
+		}
+		templates = append(templates, local...)
+	}
+	return templates
+}
+
+// applyRegistries merges the templates of the configured registries, skipping unreadable registries
+func (wr *WizardRunner) applyRegistries(ctx context.Context, locations []string, templates []models.Template) []models.Template {
+	for _, location := range locations {
+		registry, err := wizard.LoadRegistry(ctx, wr.githubClient, location)
+		if err != nil {
+			fmt.Printf("⚠️  Skipping template registry: %v\n", err)
+			continue
+		}
+		templates = wizard.ApplyRegistry(templates, registry, os.Stdout)
+	}
+	return templates
+}
+
+// searchTemplates searches public templates on GitHub, keeping the results the template validator accepts
+func (wr *WizardRunner) searchTemplates(ctx context.Context, keywords string) ([]models.Template, error) {
+	fmt.Printf("🔍 Searching GitHub for templates matching '%s'...\n", keywords)
+
+	results, err := wr.githubClient.SearchTemplates(ctx, keywords)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := wizard.NewTemplateValidator(results).Valid(results)
+	if len(templates) == 0 {
+		return nil, models.NewValidationError(fmt.Sprintf("No templates match '%s'", keywords))
+	}
+
+	fmt.Printf("✅ Found %d templates\n", len(templates))
+	return templates, nil
 }
 
 // checkPrerequisites checks if required commands are available
 func (wr *WizardRunner) checkPrerequisites(ctx context.Context) error {
-	// Check git command availability
-	if _, err := exec.LookPath("git"); err != nil {
-		return models.NewValidationError("Git command not found. Please install Git.")
+	// The native git backend works without the git command
+	if utils.UsesGitExecutable(utils.DefaultGitBackend()) {
+		if _, err := exec.LookPath("git"); err != nil {
+			return models.NewValidationError("Git command not found. Please install Git, or set git_backend to native.")
+		}
 	}
 
 	// Check GitHub CLI availability
@@ -161,12 +400,7 @@ func (wr *WizardRunner) checkPrerequisites(ctx context.Context) error {
 	}
 
 	// Check GitHub CLI authentication status
-	cmd := exec.CommandContext(ctx, "gh", "auth", "status")
-	if err := cmd.Run(); err != nil {
-		return models.NewValidationError("Not logged in to GitHub CLI. Please run 'gh auth login'.")
-	}
-
-	return nil
+	return wr.githubClient.CheckAuthentication(ctx)
 }
 
 // runNonInteractiveMode runs in non-interactive mode
@@ -180,26 +414,227 @@ func (wr *WizardRunner) runNonInteractiveMode(templates []models.Template, templ
 		LocalPath: "./" + nameFlag,
 	}
 
+	// A template may live in a subdirectory: owner/repo//path/to/template
+	reference, subdir, err := models.SplitTemplatePath(templateFlag)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set template if specified
-	if templateFlag != "" && templateFlag != "none" {
+	if wizard.IsGitURL(reference) || wizard.IsLocalTemplateRef(reference) {
+		config.Template, err = wizard.TemplateFromReference(templateFlag)
+		if err != nil {
+			return nil, err
+		}
+	} else if templateFlag != "" && templateFlag != "none" {
 		for _, tmpl := range templates {
-			if tmpl.FullName == templateFlag || tmpl.Name == templateFlag {
+			if tmpl.GetReference() == templateFlag || tmpl.Name == templateFlag {
 				config.Template = &tmpl
 				break
 			}
 		}
+		if config.Template == nil && subdir != "" {
+			config.Template, err = subdirTemplate(templates, reference, subdir)
+			if err != nil {
+				return nil, err
+			}
+		}
 		if config.Template == nil {
 			return nil, models.NewValidationError(fmt.Sprintf("Specified template '%s' not found", templateFlag))
 		}
 	}
 
+	template, err := withTemplateRef(config.Template)
+	if err != nil {
+		return nil, err
+	}
+	config.Template = template
+
 	return config, nil
 }
 
+// subdirTemplate returns the template in subdir of the owner/repo repository.
+// The repository need not be a discovered template: collections are often plain repositories.
+func subdirTemplate(templates []models.Template, repository, subdir string) (*models.Template, error) {
+	template, err := wizard.GitHubTemplate(repository)
+	if err != nil {
+		return nil, err
+	}
+	for _, tmpl := range templates {
+		if tmpl.Path == "" && strings.EqualFold(tmpl.FullName, repository) {
+			template = &tmpl
+			break
+		}
+	}
+
+	result := template.WithPath(subdir)
+	return &result, nil
+}
+
+// applyRepositoryFlags applies the flags describing the GitHub repository: --generate switches to
+// generating it on GitHub, --owner and --team choose who owns it and which teams get access, and the
+// repository option flags override the configured and prompted options.
+// Non-interactive mode creates no repository otherwise, so its visibility comes from the configuration.
+func applyRepositoryFlags(config *models.ProjectConfig, cfg *config.Config) error {
+	flagOptions, err := repositoryOptionFlags()
+	if err != nil {
+		return err
+	}
+
+	if (generateFlag || ownerFlag != "" || len(teamFlags) > 0 || noWikiFlag || !flagOptions.IsEmpty()) && !config.CreateGitHub {
+		config.CreateGitHub = true
+		config.IsPrivate = cfg.DefaultPrivate
+	}
+	if generateFlag || allBranchFlag {
+		config.GenerateOnGitHub = generateFlag
+		config.IncludeAllBranches = allBranchFlag
+	}
+
+	if ownerFlag != "" {
+		config.Owner = ownerFlag
+	} else if config.CreateGitHub && config.Owner == "" {
+		config.Owner = cfg.DefaultOwner
+	}
+
+	if config.CreateGitHub {
+		var templateSettings *models.RepositorySettings
+		if config.Manifest != nil {
+			templateSettings = config.Manifest.RepoSettings
+		}
+		config.RepoSettings = models.MergeRepositorySettings(templateSettings, cfg.RepoSettings)
+		if noWikiFlag {
+			// The wiki is a repository setting, so --no-wiki overrides repo_settings.has_wiki
+			disabled := false
+			config.RepoSettings = models.MergeRepositorySettings(config.RepoSettings, &models.RepositorySettings{HasWiki: &disabled})
+		}
+
+		config.Repository = models.MergeRepositoryOptions(cfg.Repository, config.Repository, flagOptions)
+		if config.Repository.Visibility != "" {
+			config.IsPrivate = config.Repository.Visibility != "public"
+		}
+	}
+
+	config.Teams = nil
+	for _, value := range teamFlags {
+		grant, err := models.ParseTeamGrant(value)
+		if err != nil {
+			return models.NewValidationError(err.Error())
+		}
+		config.Teams = append(config.Teams, grant)
+	}
+
+	if err := config.Validate(); err != nil {
+		return models.NewValidationError(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	return nil
+}
+
+// repositoryOptionFlags returns the repository options given by flags
+func repositoryOptionFlags() (models.RepositoryOptions, error) {
+	options := models.RepositoryOptions{
+		Visibility: visibilityFlag,
+		Homepage:   homepageFlag,
+	}
+	for _, value := range repoTopicFlags {
+		options.Topics = append(options.Topics, models.ParseTopics(value)...)
+	}
+
+	disabled := false
+	if noIssuesFlag {
+		options.HasIssues = &disabled
+	}
+	if noProjectsFlag {
+		options.HasProjects = &disabled
+	}
+
+	for _, value := range propertyFlags {
+		name, propertyValue, err := models.ParseCustomProperty(value)
+		if err != nil {
+			return models.RepositoryOptions{}, models.NewValidationError(err.Error())
+		}
+		if options.CustomProperties == nil {
+			options.CustomProperties = make(map[string]string)
+		}
+		options.CustomProperties[name] = propertyValue
+	}
+	return options, nil
+}
+
+// withTemplateRef returns the template pinned to the --ref flag
+func withTemplateRef(template *models.Template) (*models.Template, error) {
+	if refFlag == "" || template == nil {
+		return template, nil
+	}
+	if template.IsLocal() {
+		return nil, models.NewValidationError("--ref cannot be used with a local template directory")
+	}
+
+	pinned := *template
+	pinned.Ref = refFlag
+	return &pinned, nil
+}
+
+// applyTemplateVariables resolves --var flags against the selected template's manifest
+func (wr *WizardRunner) applyTemplateVariables(ctx context.Context, config *models.ProjectConfig, vars []string) error {
+	rawValues, err := parseVarFlags(vars)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := wizard.LoadTemplateManifest(ctx, wr.githubClient, config.Template)
+	if err != nil {
+		return err
+	}
+
+	if manifest == nil {
+		if len(rawValues) > 0 {
+			return models.NewValidationError("--var was specified but the template does not declare any variables")
+		}
+		return nil
+	}
+
+	values, err := manifest.ResolveValues(rawValues)
+	if err != nil {
+		return models.NewValidationError(err.Error())
+	}
+
+	config.Manifest = manifest
+	config.Variables = values
+	return nil
+}
+
+// parseVarFlags parses repeated key=value flags
+func parseVarFlags(vars []string) (map[string]string, error) {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		key, value, found := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, models.NewValidationError(fmt.Sprintf("invalid --var '%s' (expected key=value)", v))
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
 // runInteractiveMode runs in interactive mode
-func (wr *WizardRunner) runInteractiveMode(templates []models.Template) (*models.ProjectConfig, error) {
+func (wr *WizardRunner) runInteractiveMode(ctx context.Context, templates []models.Template, sortOrder models.TemplateSortOrder, filter models.TemplateFilter, defaultOwner string) (*models.ProjectConfig, error) {
 	// Use QuestionFlow from wizard package
 	flow := wizard.NewQuestionFlow(templates)
+	flow.SetSortOrder(sortOrder)
+	flow.SetFilter(filter)
+	flow.SetManifestLoader(func(template *models.Template) (*models.TemplateManifest, error) {
+		template, err := withTemplateRef(template)
+		if err != nil {
+			return nil, err
+		}
+		return wizard.LoadTemplateManifest(ctx, wr.githubClient, template)
+	})
+	if ownerFlag == "" {
+		flow.SetOwnerLoader(func() ([]string, error) {
+			return wr.githubClient.ListRepositoryOwners(ctx)
+		}, defaultOwner)
+	}
 
 	// Execute interactive questions with appropriate UI style
 	var config *models.ProjectConfig
@@ -217,6 +652,11 @@ func (wr *WizardRunner) runInteractiveMode(templates []models.Template) (*models
 		return nil, models.NewValidationError(fmt.Sprintf("Failed to execute questions: %v", err))
 	}
 
+	config.Template, err = withTemplateRef(config.Template)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set LocalPath
 	config.LocalPath = "./" + config.Name
 
@@ -260,19 +700,83 @@ func (wr *WizardRunner) printConfiguration(config *models.ProjectConfig) {
 	}
 
 	if config.Template != nil {
-		fmt.Printf("✓ Template:     %s (%d⭐)\n", config.Template.FullName, config.Template.Stars)
+		if config.Template.IsLocal() {
+			fmt.Printf("✓ Template:     %s (local)\n", config.Template.GetReference())
+		} else if config.Template.GetSource() == models.SourceGit {
+			fmt.Printf("✓ Template:     %s (git)\n", config.Template.GetReference())
+		} else {
+			fmt.Printf("✓ Template:     %s (%d⭐)\n", config.Template.GetReference(), config.Template.Stars)
+		}
 	} else {
 		fmt.Println("✓ Template:     None")
 	}
 
+	if config.Template != nil && config.Template.Ref != "" {
+		fmt.Printf("✓ Template Ref: %s\n", config.Template.Ref)
+	}
+
+	if len(config.Variables) > 0 {
+		fmt.Println("✓ Variables:")
+		keys := make([]string, 0, len(config.Variables))
+		for key := range config.Variables {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("    %s = %v\n", key, config.Variables[key])
+		}
+	}
+
+	if config.Manifest != nil && len(config.Manifest.Hooks.All()) > 0 {
+		if noHooksFlag {
+			fmt.Println("✓ Hooks:        Skipped (--no-hooks)")
+		} else {
+			fmt.Println("✓ Hooks:")
+			for _, hook := range config.Manifest.Hooks.All() {
+				fmt.Printf("    $ %s\n", hook.Run)
+			}
+		}
+	}
+
 	fmt.Printf("✓ Local Path:   %s\n", config.LocalPath)
 
 	if config.CreateGitHub {
-		if config.IsPrivate {
+		if config.Owner != "" {
+			fmt.Printf("✓ Owner:        %s\n", config.Owner)
+		}
+		if config.Repository.Visibility != "" {
+			fmt.Printf("✓ Visibility:   %s\n", config.Repository.Visibility)
+		} else if config.IsPrivate {
 			fmt.Println("✓ Private:      True")
 		} else {
 			fmt.Println("✓ Private:      False")
 		}
+		if options := config.Repository.GetDisplaySummary(); len(options) > 0 {
+			fmt.Println("✓ Repository:")
+			for _, line := range options {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+		if len(config.Teams) > 0 {
+			fmt.Println("✓ Teams:")
+			for _, grant := range config.Teams {
+				fmt.Printf("    %s (%s)\n", grant.Team, grant.Permission)
+			}
+		}
+		if settings := config.RepoSettings.GetDisplaySummary(); len(settings) > 0 {
+			fmt.Println("✓ Settings:")
+			for _, line := range settings {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+
+	if config.GenerateOnGitHub {
+		if config.IncludeAllBranches {
+			fmt.Println("✓ GitHub:       Generate from template (all branches)")
+		} else {
+			fmt.Println("✓ GitHub:       Generate from template")
+		}
 	}
 }
 
@@ -293,283 +797,26 @@ func (wr *WizardRunner) confirmConfiguration() (bool, error) {
 
 // createProject executes the actual project creation
 func (wr *WizardRunner) createProject(ctx context.Context, config *models.ProjectConfig) error {
-	fmt.Printf("🚀 Creating project '%s'...\n", config.Name)
-
-	// Check for context cancellation
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
-	}
-
-	// 1. Create local directory
-	if err := os.MkdirAll(config.LocalPath, 0755); err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to create directory: %v", err))
-	}
-
-	// 2. Copy files from template (if applicable)
-	if config.Template != nil {
-		// Check for context cancellation
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		fmt.Printf("📦 Applying template '%s'...\n", config.Template.FullName)
-		if err := wr.copyTemplateFiles(ctx, config); err != nil {
-			return err
-		}
-	} else {
-		// Create basic files only if no template
-		if err := wr.createBasicFiles(config); err != nil {
-			return err
-		}
-	}
-
-	// 3. Git initialization (completely remove template's .git first)
-	gitDirPath := filepath.Join(config.LocalPath, ".git")
-	if err := os.RemoveAll(gitDirPath); err != nil {
-		fmt.Printf("⚠️  Failed to remove existing .git directory: %v\n", err)
-	}
-
-	gitInit := exec.CommandContext(ctx, "git", "init")
-	gitInit.Dir = config.LocalPath
-	if err := gitInit.Run(); err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to initialize Git: %v", err))
-	}
-
-	// 4. Create GitHub repository (if applicable)
-	if config.CreateGitHub {
-		// Check for context cancellation
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		fmt.Printf("🐙 Creating GitHub repository...\n")
-		if err := wr.createGitHubRepository(ctx, config); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	executor := wizard.NewProjectExecutor(wr.githubClient)
+	executor.SetHooksEnabled(wr.runHooks)
+	return executor.Execute(ctx, config)
 }
 
-// copyTemplateFiles copies files from template repository
-func (wr *WizardRunner) copyTemplateFiles(ctx context.Context, config *models.ProjectConfig) error {
-	// Create temporary directory and clone template repository
-	tempDir, err := os.MkdirTemp("", "gh-wizard-template-*")
-	if err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to create temporary directory: %v", err))
+// printSettingsRequests lists the REST calls that would apply the repository settings.
+// The owner is shown as {owner} when it is only known once the repository is created.
+func printSettingsRequests(config *models.ProjectConfig) {
+	if !config.CreateGitHub || config.RepoSettings.IsEmpty() {
+		return
 	}
-	defer os.RemoveAll(tempDir) // Cleanup
 
-	// Clone template repository
-	cloneCmd := exec.CommandContext(ctx, "gh", "repo", "clone", config.Template.FullName, tempDir)
-	if err := cloneCmd.Run(); err != nil {
-		return models.NewGitHubError(fmt.Sprintf("Failed to clone template repository: %v", err), err)
+	owner := config.Owner
+	if owner == "" {
+		owner = "{owner}"
 	}
 
-	// Copy files excluding .git directory
-	if err := wr.copyDirectoryContents(tempDir, config.LocalPath, []string{".git"}); err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to copy template files: %v", err))
+	fmt.Println("🔍 Repository settings would be applied with:")
+	for _, request := range github.RepositorySettingsRequests(owner+"/"+config.Name, config.RepoSettings) {
+		fmt.Printf("    %s\n", request)
 	}
-
-	// Update project name and description (if README.md exists)
-	if err := wr.updateTemplateVariables(config); err != nil {
-		// Continue template application even if error occurs
-		fmt.Printf("⚠️  Failed to update template variables: %v\n", err)
-	}
-
-	return nil
-}
-
-// copyDirectoryContents copies directory contents to another directory (with exclusion list support)
-func (wr *WizardRunner) copyDirectoryContents(srcDir, dstDir string, excludeDirs []string) error {
-	return filepath.Walk(srcDir, func(srcPath string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Get relative path
-		relPath, err := filepath.Rel(srcDir, srcPath)
-		if err != nil {
-			return err
-		}
-
-		// Skip root directory
-		if relPath == "." {
-			return nil
-		}
-
-		// Check for excluded directories
-		for _, excludeDir := range excludeDirs {
-			if strings.HasPrefix(relPath, excludeDir) {
-				if info.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
-		}
-
-		dstPath := filepath.Join(dstDir, relPath)
-
-		if info.IsDir() {
-			// Create directory
-			return os.MkdirAll(dstPath, info.Mode())
-		} else {
-			// Copy file
-			return wr.copyFile(srcPath, dstPath)
-		}
-	})
-}
-
-// copyFile copies a file
-func (wr *WizardRunner) copyFile(srcPath, dstPath string) error {
-	// Create directory if it doesn't exist
-	if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
-		return err
-	}
-
-	srcFile, err := os.Open(srcPath)
-	if err != nil {
-		return err
-	}
-	defer srcFile.Close()
-
-	dstFile, err := os.Create(dstPath)
-	if err != nil {
-		return err
-	}
-	defer dstFile.Close()
-
-	_, err = io.Copy(dstFile, srcFile)
-	return err
-}
-
-// updateTemplateVariables updates variables within template
-func (wr *WizardRunner) updateTemplateVariables(config *models.ProjectConfig) error {
-	readmePath := filepath.Join(config.LocalPath, "README.md")
-
-	// Check if README.md exists
-	if _, err := os.Stat(readmePath); os.IsNotExist(err) {
-		// Create basic README if README.md doesn't exist
-		return wr.createBasicFiles(config)
-	}
-
-	// Read README.md
-	content, err := os.ReadFile(readmePath)
-	if err != nil {
-		return err
-	}
-
-	// Replace template variables (simple example)
-	contentStr := string(content)
-
-	// Replace common template variables
-	replacements := map[string]string{
-		"{{PROJECT_NAME}}": config.Name,
-		"{{project_name}}": config.Name,
-		"{{DESCRIPTION}}":  config.Description,
-		"{{description}}":  config.Description,
-		"${PROJECT_NAME}":  config.Name,
-		"${project_name}":  config.Name,
-		"${DESCRIPTION}":   config.Description,
-		"${description}":   config.Description,
-	}
-
-	for placeholder, value := range replacements {
-		if value != "" { // Don't replace if value is empty
-			contentStr = strings.ReplaceAll(contentStr, placeholder, value)
-		}
-	}
-
-	// Write back updated content
-	return os.WriteFile(readmePath, []byte(contentStr), 0644)
-}
-
-// createBasicFiles creates basic files
-func (wr *WizardRunner) createBasicFiles(config *models.ProjectConfig) error {
-	// Create README.md
-	readmeContent := fmt.Sprintf("# %s\n\n%s\n", config.Name, config.Description)
-	readmePath := fmt.Sprintf("%s/README.md", config.LocalPath)
-
-	if err := os.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to create README.md: %v", err))
-	}
-
-	return nil
 }
 
-// createGitHubRepository creates a GitHub repository
-func (wr *WizardRunner) createGitHubRepository(ctx context.Context, config *models.ProjectConfig) error {
-	// Initial commit and file addition
-	addCmd := exec.CommandContext(ctx, "git", "add", ".")
-	addCmd.Dir = config.LocalPath
-	if err := addCmd.Run(); err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to stage files: %v", err))
-	}
-
-	commitCmd := exec.CommandContext(ctx, "git", "commit", "-m", "Initial commit")
-	commitCmd.Dir = config.LocalPath
-	if err := commitCmd.Run(); err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to create initial commit: %v", err))
-	}
-
-	// 1. Create GitHub repository (without push)
-	args := []string{"repo", "create", config.Name}
-
-	if config.Description != "" {
-		args = append(args, "--description", config.Description)
-	}
-
-	if config.IsPrivate {
-		args = append(args, "--private")
-	} else {
-		args = append(args, "--public")
-	}
-
-	createCmd := exec.CommandContext(ctx, "gh", args...)
-	createCmd.Dir = config.LocalPath
-
-	if output, err := createCmd.CombinedOutput(); err != nil {
-		fmt.Printf("Error output: %s\n", string(output))
-		return models.NewGitHubError(fmt.Sprintf("Failed to create GitHub repository: %s", string(output)), err)
-	}
-
-	// 2. Get GitHub username
-	userCmd := exec.CommandContext(ctx, "gh", "api", "user", "--jq", ".login")
-	userOutput, err := userCmd.Output()
-	if err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to get GitHub username: %v", err))
-	}
-	username := strings.TrimSpace(string(userOutput))
-
-	// 3. Add remote repository
-	remoteCmd := exec.CommandContext(ctx, "git", "remote", "add", "origin", fmt.Sprintf("https://github.com/%s/%s.git", username, config.Name))
-	remoteCmd.Dir = config.LocalPath
-	if err := remoteCmd.Run(); err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to add remote repository: %v", err))
-	}
-
-	// 4. Get current branch name
-	branchCmd := exec.CommandContext(ctx, "git", "branch", "--show-current")
-	branchCmd.Dir = config.LocalPath
-	branchOutput, err := branchCmd.Output()
-	if err != nil {
-		return models.NewValidationError(fmt.Sprintf("Failed to get current branch name: %v", err))
-	}
-	currentBranch := strings.TrimSpace(string(branchOutput))
-
-	// 5. Push current branch
-	pushCmd := exec.CommandContext(ctx, "git", "push", "-u", "origin", currentBranch)
-	pushCmd.Dir = config.LocalPath
-	if output, err := pushCmd.CombinedOutput(); err != nil {
-		fmt.Printf("Push error (%s): %s\n", currentBranch, string(output))
-		return models.NewGitHubError(fmt.Sprintf("Failed to push to repository: %s", string(output)), err)
-	}
-
-	return nil
-}
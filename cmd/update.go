@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/utils"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/wizard"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateRefFlag   string
+	updateForceFlag bool
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update [path]",
+	Short: "Apply upstream template changes to a generated project",
+	Long: "Re-render the template a project was generated from at a newer commit and merge the changes " +
+		"into the project. Conflicting changes are left as conflict markers or .rej files.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUpdate,
+}
+
+func init() {
+	updateCmd.Flags().StringVar(&updateRefFlag, "ref", "", "Template branch, tag or commit to update to (default: the recorded ref or the template's default branch)")
+	updateCmd.Flags().BoolVar(&updateForceFlag, "force", false, "Update even if the project has uncommitted changes")
+	rootCmd.AddCommand(updateCmd)
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	runner := NewWizardRunner()
+
+	projectDir := "."
+	if len(args) > 0 {
+		projectDir = args[0]
+	}
+
+	result, err := runner.updateProject(ctx, projectDir)
+	if err != nil {
+		return runner.handleError(err)
+	}
+
+	printUpdateResult(result)
+	return nil
+}
+
+// updateProject merges upstream template changes into the project
+func (wr *WizardRunner) updateProject(ctx context.Context, projectDir string) (*wizard.UpdateResult, error) {
+	// Merging into uncommitted work would make conflicts hard to undo
+	if _, err := os.Stat(filepath.Join(projectDir, ".git")); err == nil && !updateForceFlag {
+		dirty, err := utils.NewGitService(projectDir).HasUncommittedChanges(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if dirty {
+			return nil, models.NewValidationError(
+				"The project has uncommitted changes. Commit or stash them first, or use --force",
+			)
+		}
+	}
+
+	lock, err := wizard.ReadProjectLock(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("🔄 Fetching template '%s'...\n", lock.GetTemplate().Name)
+	return wizard.NewTemplateUpdater(projectDir).Update(ctx, updateRefFlag)
+}
+
+// printUpdateResult displays the files touched by an update
+func printUpdateResult(result *wizard.UpdateResult) {
+	if result.UpToDate() {
+		fmt.Printf("✅ Already up to date with template commit %s\n", wizard.ShortCommit(result.ToCommit))
+		return
+	}
+
+	fmt.Printf("📦 Updated template %s → %s\n", wizard.ShortCommit(result.FromCommit), wizard.ShortCommit(result.ToCommit))
+	for _, file := range result.Files {
+		switch file.Action {
+		case wizard.UpdateActionConflict:
+			fmt.Printf("  ⚠️  %-9s %s (resolve conflict markers)\n", file.Action, file.Path)
+		case wizard.UpdateActionRejected:
+			fmt.Printf("  ⚠️  %-9s %s (see %s.rej)\n", file.Action, file.Path, file.Path)
+		case wizard.UpdateActionKept:
+			fmt.Printf("  ⚠️  %-9s %s (removed from template but changed locally)\n", file.Action, file.Path)
+		default:
+			fmt.Printf("  ✓ %-9s %s\n", file.Action, file.Path)
+		}
+	}
+
+	if len(result.Files) == 0 {
+		fmt.Println("  No template files changed")
+	}
+
+	if result.HasConflicts() {
+		fmt.Println("⚠️  Some changes need manual attention. Review the files above before committing.")
+	} else {
+		fmt.Println("✨ Template changes applied. Review them with `git diff` before committing.")
+	}
+}
@@ -7,10 +7,16 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/config"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/utils"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/wizard"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -208,6 +214,135 @@ func TestWizardRunner_NonInteractiveMode(t *testing.T) {
 	}
 }
 
+func TestWizardRunner_LocalTemplate(t *testing.T) {
+	templateDir := filepath.Join(t.TempDir(), "skeleton")
+	require.NoError(t, os.Mkdir(templateDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templateDir, "main.go"), []byte("package main\n"), 0644))
+
+	runner := &WizardRunner{}
+
+	// --template にローカルパスを指定できる
+	config, err := runner.runNonInteractiveMode(nil, "file://"+filepath.ToSlash(templateDir), "local-project")
+	require.NoError(t, err)
+	require.NotNil(t, config.Template)
+	assert.True(t, config.Template.IsLocal())
+	assert.Equal(t, templateDir, config.Template.CloneURL)
+
+	// git 管理外のディレクトリはそのままコピーし、ロックファイルは作らない
+	config.LocalPath = filepath.Join(t.TempDir(), "local-project")
+	require.NoError(t, runner.createProject(context.Background(), config))
+
+	content, err := os.ReadFile(filepath.Join(config.LocalPath, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(content))
+	_, err = os.Stat(filepath.Join(config.LocalPath, models.LockFileName))
+	assert.True(t, os.IsNotExist(err))
+
+	// 存在しないディレクトリは設定から読み込むときに除外する
+	templates := runner.localTemplates([]string{templateDir, filepath.Join(templateDir, "missing")})
+	require.Len(t, templates, 1)
+	assert.Equal(t, "skeleton", templates[0].Name)
+}
+
+func TestWizardRunner_GitTemplateRef(t *testing.T) {
+	defer func() { refFlag = "" }()
+	runner := &WizardRunner{}
+
+	// git URL は検索せずにテンプレートとして使い、--ref を付ける
+	refFlag = "develop"
+	config, err := runner.runNonInteractiveMode(nil, "git@gitea.example.com:platform/service.git", "service")
+	require.NoError(t, err)
+	assert.Equal(t, models.SourceGit, config.Template.Source)
+	assert.Equal(t, "git@gitea.example.com:platform/service.git", config.Template.CloneURL)
+	assert.Equal(t, "develop", config.Template.Ref)
+
+	// GitHub のテンプレートにも --ref を指定できる
+	templates := []models.Template{{Name: "template", FullName: "user/template", IsTemplate: true}}
+	config, err = runner.runNonInteractiveMode(templates, "user/template", "service")
+	require.NoError(t, err)
+	assert.Equal(t, "develop", config.Template.Ref)
+	assert.Empty(t, templates[0].Ref)
+
+	// ローカルディレクトリには --ref を使えない
+	_, err = runner.runNonInteractiveMode(nil, t.TempDir(), "service")
+	assert.ErrorContains(t, err, "--ref cannot be used")
+}
+
+func TestParseVarFlags(t *testing.T) {
+	values, err := parseVarFlags([]string{"service=billing", "url=http://example.com/?a=b", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"service": "billing",
+		"url":     "http://example.com/?a=b",
+		"empty":   "",
+	}, values)
+
+	_, err = parseVarFlags([]string{"novalue"})
+	assert.Error(t, err)
+
+	_, err = parseVarFlags([]string{"=value"})
+	assert.Error(t, err)
+}
+
+func TestMergeOwners(t *testing.T) {
+	// 設定とフラグの組織を重複なく結合する
+	owners := mergeOwners([]string{"acme", " "}, []string{"ACME", "acme/platform"})
+	assert.Equal(t, []string{"acme", "acme/platform"}, owners)
+}
+
+func TestTemplateFilter(t *testing.T) {
+	cfg := config.GetDefault()
+	cfg.Profile = "backend"
+	cfg.Profiles = map[string]config.Profile{
+		"backend":  {Topics: []string{"backend"}, Language: "Go"},
+		"frontend": {Topics: []string{"frontend"}},
+	}
+	defer func() {
+		topicFlags, languageFlag, profileFlag = nil, "", ""
+	}()
+
+	// プロファイルの既定値を使う
+	filter, err := templateFilter(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, models.TemplateFilter{Topics: []string{"backend"}, Language: "Go"}, filter)
+
+	// フラグはプロファイルより優先する
+	profileFlag = "frontend"
+	languageFlag = "TypeScript"
+	filter, err = templateFilter(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, models.TemplateFilter{Topics: []string{"frontend"}, Language: "TypeScript"}, filter)
+
+	topicFlags = []string{"internal-only"}
+	filter, err = templateFilter(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"internal-only"}, filter.Topics)
+
+	profileFlag = "unknown"
+	_, err = templateFilter(cfg)
+	assert.Error(t, err)
+}
+
+func TestWizardRunner_SearchTemplates(t *testing.T) {
+	client := github.NewSimpleMockClient()
+	client.Templates = append(client.Templates, models.Template{
+		Name:        "go-cli-demo",
+		FullName:    "someone/go-cli-demo",
+		Description: "Go CLI demo (not a template)",
+	})
+	runner := &WizardRunner{githubClient: client}
+
+	// テンプレートでない検索結果は除外する
+	templates, err := runner.searchTemplates(context.Background(), "go-cli")
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "testuser/go-cli-template", templates[0].FullName)
+
+	// 一致するテンプレートがなければエラー
+	_, err = runner.searchTemplates(context.Background(), "fastapi")
+	assert.ErrorContains(t, err, "No templates match 'fastapi'")
+}
+
 func TestWizardRunner_HandleError(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -282,6 +417,11 @@ func TestWizardRunner_PrintConfiguration(t *testing.T) {
 		CreateGitHub: true,
 		IsPrivate:    true,
 		LocalPath:    "./test-project",
+		Repository:   models.RepositoryOptions{Topics: []string{"go", "cli"}},
+		RepoSettings: &models.RepositorySettings{
+			MergeStrategies:  []string{"squash"},
+			BranchProtection: []models.BranchProtection{{Branch: "main", RequiredReviews: 1}},
+		},
 	}
 
 	runner := NewWizardRunner()
@@ -304,8 +444,9 @@ func TestWizardRunner_PrintConfiguration(t *testing.T) {
 		done <- true
 	}()
 
-	// 設定表示実行
+	// 設定表示実行（ドライランで表示する API 呼び出しも含む）
 	runner.printConfiguration(config)
+	printSettingsRequests(config)
 
 	// パイプを閉じて出力完了を待つ
 	w.Close()
@@ -319,6 +460,11 @@ func TestWizardRunner_PrintConfiguration(t *testing.T) {
 	assert.Contains(t, output, "test-project")
 	assert.Contains(t, output, "Test description")
 	assert.Contains(t, output, "Private")
+	assert.Contains(t, output, "Topics: go, cli")
+	assert.Contains(t, output, "Merge: squash only")
+	assert.Contains(t, output, "Protect main: 1 review(s)")
+	assert.Contains(t, output, "PATCH repos/{owner}/test-project")
+	assert.Contains(t, output, "PUT repos/{owner}/test-project/branches/main/protection")
 }
 
 func TestWizardRunner_Performance(t *testing.T) {
@@ -364,3 +510,321 @@ func BenchmarkWizardRunner_NonInteractiveMode(b *testing.B) {
 }
 
 // 注意: WizardRunner の実装は cmd/wizard.go に移動済み
+
+func TestWizardRunner_SubdirTemplate(t *testing.T) {
+	runner := &WizardRunner{}
+	templates := []models.Template{
+		{Name: "templates", FullName: "acme/templates", Description: "Platform templates", Stars: 12, IsTemplate: true},
+		{Name: "go-service", FullName: "acme/templates", Path: "services/go", IsTemplate: true},
+	}
+
+	// 展開済みのサブテンプレートは参照で選べる
+	config, err := runner.runNonInteractiveMode(templates, "acme/templates//services/go", "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "go-service", config.Template.Name)
+
+	// 宣言されていないサブディレクトリもリポジトリの情報を引き継いで使える
+	config, err = runner.runNonInteractiveMode(templates, "acme/templates//tools/cli", "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "cli", config.Template.Name)
+	assert.Equal(t, "tools/cli", config.Template.Path)
+	assert.Equal(t, 12, config.Template.Stars)
+
+	// 検出されていないリポジトリでもよい
+	config, err = runner.runNonInteractiveMode(nil, "other/monorepo//templates/web", "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "other/monorepo", config.Template.FullName)
+	assert.Equal(t, "other", config.Template.Owner)
+
+	// git URL にもサブディレクトリを指定できる
+	config, err = runner.runNonInteractiveMode(nil, "https://gitea.example.com/platform/templates.git//web", "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/platform/templates.git", config.Template.CloneURL)
+	assert.Equal(t, "web", config.Template.Path)
+
+	_, err = runner.runNonInteractiveMode(nil, "acme//web", "billing")
+	assert.Error(t, err)
+	_, err = runner.runNonInteractiveMode(nil, "acme/templates//../web", "billing")
+	assert.Error(t, err)
+
+	// ローカルのコレクションはサブディレクトリだけをコピーする
+	collectionDir := filepath.Join(t.TempDir(), "templates")
+	for name, content := range map[string]string{
+		models.ManifestFileName: "templates:\n  - path: web\n",
+		"CODEOWNERS":            "* @acme/platform\n",
+		"web/index.html":        "<html></html>\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(collectionDir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(collectionDir, name), []byte(content), 0644))
+	}
+
+	local := runner.localTemplates([]string{collectionDir})
+	require.Len(t, local, 1)
+	config = &models.ProjectConfig{Name: "site", LocalPath: filepath.Join(t.TempDir(), "site"), Template: &local[0]}
+	require.NoError(t, runner.createProject(context.Background(), config))
+
+	assert.FileExists(t, filepath.Join(config.LocalPath, "index.html"))
+	assert.NoFileExists(t, filepath.Join(config.LocalPath, "CODEOWNERS"))
+}
+
+func TestWizardRunner_ApplyRegistries(t *testing.T) {
+	registryFile := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(registryFile, []byte(`{"templates": [{"template": "acme/go-service", "category": "Backend"}]}`), 0644))
+
+	runner := &WizardRunner{githubClient: github.NewSimpleMockClient()}
+	templates := []models.Template{{Name: "go-service", FullName: "acme/go-service", IsTemplate: true}}
+
+	// 読めないレジストリは飛ばし、読めたレジストリを反映する
+	result := runner.applyRegistries(context.Background(), []string{filepath.Join(t.TempDir(), "missing.yaml"), registryFile}, templates)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Backend", result[0].Category)
+	assert.True(t, result[0].Recommended)
+}
+
+func TestWizardRunner_CreateGitHubRepository(t *testing.T) {
+	tempDir := t.TempDir()
+	remotePath := filepath.Join(tempDir, "remote.git")
+	require.NoError(t, exec.Command("git", "init", "--bare", remotePath).Run())
+
+	projectPath := filepath.Join(tempDir, "billing")
+
+	// リポジトリ作成はクライアント経由で行い、返された CloneURL にプッシュする
+	client := github.NewSimpleMockClient()
+	client.Repository = &github.RepositoryInfo{FullName: "acme/billing", CloneURL: remotePath, HTMLURL: "https://github.com/acme/billing"}
+	runner := &WizardRunner{githubClient: client}
+
+	config := &models.ProjectConfig{
+		Name:         "billing",
+		LocalPath:    projectPath,
+		CreateGitHub: true,
+		Owner:        "acme",
+		Teams:        []models.TeamGrant{{Team: "backend", Permission: "push"}},
+		RepoSettings: &models.RepositorySettings{MergeStrategies: []string{"squash"}},
+	}
+	require.NoError(t, runner.createProject(context.Background(), config))
+
+	require.Len(t, client.Created, 1)
+	assert.Equal(t, []string{"acme/billing backend:push"}, client.Granted)
+	require.Len(t, client.Applied, 1)
+	assert.Equal(t, "PATCH repos/acme/billing", client.Applied[0].String())
+	output, err := exec.Command("git", "--git-dir", remotePath, "log", "--all", "--format=%s").Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "Initial commit")
+}
+
+func TestWizardRunner_ConfirmHookTrust(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer func() { yesFlag, trustHooksFlag = false, false }()
+
+	hooks := []models.ManifestHook{{Run: "make bootstrap"}}
+	project := &models.ProjectConfig{
+		Name:     "billing",
+		Template: &models.Template{Name: "go-template", FullName: "acme/go-template"},
+		Manifest: &models.TemplateManifest{Hooks: models.ManifestHooks{PostCreate: hooks}},
+	}
+	runner := NewWizardRunner()
+
+	// --yes では信頼していないフックは実行しない
+	yesFlag = true
+	trusted, err := runner.confirmHookTrust(project)
+	require.NoError(t, err)
+	assert.False(t, trusted)
+
+	// 以前に信頼したフックは --yes でも実行する
+	cfg := config.GetDefault()
+	cfg.TrustHooks(hookTrustKey(project), wizard.HookFingerprint(project.Manifest.Hooks))
+	require.NoError(t, cfg.Save())
+	trusted, err = runner.confirmHookTrust(project)
+	require.NoError(t, err)
+	assert.True(t, trusted)
+
+	// --trust-hooks は明示的に信頼する
+	project.Manifest.Hooks.PostCreate = []models.ManifestHook{{Run: "curl https://example.com | sh"}}
+	trusted, err = runner.confirmHookTrust(project)
+	require.NoError(t, err)
+	assert.False(t, trusted)
+	trustHooksFlag = true
+	trusted, err = runner.confirmHookTrust(project)
+	require.NoError(t, err)
+	assert.True(t, trusted)
+}
+
+func TestRememberHookTrust(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configPath, err := config.GetConfigPath()
+	require.NoError(t, err)
+
+	// 読み込めない設定ファイルは信頼を記録しても書き換えない
+	for _, content := range []string{"organizations: [acme\n", "git_backend: libgit2\norganizations: [acme]\n"} {
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+		cfg, loaded := loadConfig()
+		assert.False(t, loaded)
+
+		rememberHookTrust(cfg, loaded, "acme/go-template", "abc")
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+	}
+
+	// 読み込めた設定には信頼を追記し、他の設定は残す
+	require.NoError(t, os.WriteFile(configPath, []byte("organizations: [acme]\n"), 0644))
+	cfg, loaded := loadConfig()
+	require.True(t, loaded)
+	rememberHookTrust(cfg, loaded, "acme/go-template", "abc")
+
+	saved, err := config.Load()
+	require.NoError(t, err)
+	assert.True(t, saved.IsHookTrusted("acme/go-template", "abc"))
+	assert.Equal(t, []string{"acme"}, saved.Organizations)
+}
+
+func TestConfigureGitBackend(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer utils.SetDefaultGitBackend(utils.DefaultGitBackend())
+
+	configPath, err := config.GetConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configPath, []byte("git_backend: subprocess\n"), 0644))
+
+	// 設定で選んだバックエンドが既定になる
+	require.NoError(t, configureGitBackend(rootCmd, nil))
+	assert.Equal(t, utils.NewSubprocessGitBackend(), utils.DefaultGitBackend())
+}
+
+func TestApplyRepositoryFlags_Generate(t *testing.T) {
+	defer func() { generateFlag, allBranchFlag = false, false }()
+	template := &models.Template{Name: "go-template", FullName: "acme/go-template", IsTemplate: true}
+	cfg := &config.Config{DefaultPrivate: true}
+
+	// --generate は GitHub リポジトリの作成を伴う
+	generateFlag, allBranchFlag = true, true
+	project := &models.ProjectConfig{Name: "billing", Template: template}
+	require.NoError(t, applyRepositoryFlags(project, cfg))
+	assert.True(t, project.CreateGitHub)
+	assert.True(t, project.IsPrivate)
+	assert.True(t, project.GenerateOnGitHub)
+	assert.True(t, project.IncludeAllBranches)
+
+	// ローカルテンプレートは GitHub 上で生成できない
+	project = &models.ProjectConfig{Name: "billing", Template: &models.Template{Name: "skeleton", CloneURL: "./skeleton", Source: models.SourceLocal}}
+	assert.Error(t, applyRepositoryFlags(project, cfg))
+
+	// --include-all-branches だけでは使えない
+	generateFlag = false
+	project = &models.ProjectConfig{Name: "billing", Template: template}
+	assert.Error(t, applyRepositoryFlags(project, cfg))
+}
+
+func TestApplyRepositoryFlags_RepoSettings(t *testing.T) {
+	wiki := false
+	cfg := &config.Config{RepoSettings: &models.RepositorySettings{HasWiki: &wiki}}
+	manifest := &models.TemplateManifest{RepoSettings: &models.RepositorySettings{MergeStrategies: []string{"squash"}}}
+
+	// テンプレートとユーザー設定の両方が反映される
+	project := &models.ProjectConfig{Name: "billing", CreateGitHub: true, Manifest: manifest}
+	require.NoError(t, applyRepositoryFlags(project, cfg))
+	require.NotNil(t, project.RepoSettings)
+	assert.Equal(t, []string{"squash"}, project.RepoSettings.MergeStrategies)
+	assert.False(t, *project.RepoSettings.HasWiki)
+
+	// GitHub リポジトリを作らなければ設定もない
+	project = &models.ProjectConfig{Name: "billing", Manifest: manifest}
+	require.NoError(t, applyRepositoryFlags(project, cfg))
+	assert.Nil(t, project.RepoSettings)
+}
+
+func TestRootFlags_Owners(t *testing.T) {
+	// --owner は作成先の所有者で、テンプレートを探す組織は --template-owner（繰り返し可）で指定する
+	assert.Equal(t, "string", rootCmd.Flags().Lookup("owner").Value.Type())
+	assert.Equal(t, "stringArray", rootCmd.Flags().Lookup("template-owner").Value.Type())
+	assert.Nil(t, rootCmd.Flags().Lookup("repo-owner"))
+	assert.Nil(t, rootCmd.Flags().Lookup("org"))
+}
+
+func TestApplyRepositoryFlags_OwnerAndTeams(t *testing.T) {
+	defer func() { ownerFlag, teamFlags = "", nil }()
+	cfg := &config.Config{DefaultPrivate: true, DefaultOwner: "acme"}
+
+	// 設定のデフォルトオーナーは GitHub リポジトリを作るときだけ使う
+	project := &models.ProjectConfig{Name: "billing", CreateGitHub: true}
+	require.NoError(t, applyRepositoryFlags(project, cfg))
+	assert.Equal(t, "acme", project.Owner)
+
+	project = &models.ProjectConfig{Name: "billing"}
+	require.NoError(t, applyRepositoryFlags(project, cfg))
+	assert.Empty(t, project.Owner)
+
+	// --owner と --team は GitHub リポジトリの作成を伴う
+	ownerFlag, teamFlags = "platform", []string{"backend:maintain", "sre"}
+	project = &models.ProjectConfig{Name: "billing"}
+	require.NoError(t, applyRepositoryFlags(project, cfg))
+	assert.True(t, project.CreateGitHub)
+	assert.True(t, project.IsPrivate)
+	assert.Equal(t, "platform", project.Owner)
+	assert.Equal(t, []models.TeamGrant{{Team: "backend", Permission: "maintain"}, {Team: "sre", Permission: "push"}}, project.Teams)
+
+	// 未知の権限はエラー
+	teamFlags = []string{"backend:write"}
+	assert.Error(t, applyRepositoryFlags(&models.ProjectConfig{Name: "billing"}, cfg))
+
+	// チームには組織のオーナーが必要
+	ownerFlag, teamFlags = "", []string{"backend"}
+	assert.Error(t, applyRepositoryFlags(&models.ProjectConfig{Name: "billing"}, &config.Config{}))
+}
+
+func TestApplyRepositoryFlags_RepositoryOptions(t *testing.T) {
+	defer func() {
+		repoTopicFlags, homepageFlag, visibilityFlag, noWikiFlag, propertyFlags = nil, "", "", false, nil
+	}()
+	cfg := &config.Config{
+		DefaultOwner: "acme",
+		Repository:   models.RepositoryOptions{Topics: []string{"backend"}, CustomProperties: map[string]string{"team": "platform"}},
+	}
+
+	// 設定・質問・フラグの順に上書きし、トピックは足し合わせる
+	repoTopicFlags, homepageFlag, visibilityFlag = []string{"Go,cli"}, "https://example.com", "internal"
+	noWikiFlag, propertyFlags = true, []string{"tier=1"}
+	project := &models.ProjectConfig{Name: "billing", Repository: models.RepositoryOptions{Topics: []string{"billing"}}}
+	require.NoError(t, applyRepositoryFlags(project, cfg))
+	assert.True(t, project.CreateGitHub)
+	assert.True(t, project.IsPrivate)
+	assert.Equal(t, []string{"backend", "billing", "go", "cli"}, project.Repository.Topics)
+	assert.Equal(t, "https://example.com", project.Repository.Homepage)
+	assert.Equal(t, "internal", project.Repository.Visibility)
+	assert.False(t, *project.RepoSettings.HasWiki)
+	assert.Nil(t, project.Repository.HasIssues)
+	assert.Equal(t, map[string]string{"team": "platform", "tier": "1"}, project.Repository.CustomProperties)
+
+	// public を指定すれば公開リポジトリになる
+	visibilityFlag = "public"
+	project = &models.ProjectConfig{Name: "billing", CreateGitHub: true, IsPrivate: true}
+	require.NoError(t, applyRepositoryFlags(project, cfg))
+	assert.False(t, project.IsPrivate)
+
+	// 不正な値はエラー
+	visibilityFlag, propertyFlags = "secret", nil
+	assert.Error(t, applyRepositoryFlags(&models.ProjectConfig{Name: "billing"}, cfg))
+	visibilityFlag, propertyFlags = "", []string{"tier"}
+	assert.Error(t, applyRepositoryFlags(&models.ProjectConfig{Name: "billing"}, cfg))
+}
+
+func TestWizardRunner_BootstrapRepository(t *testing.T) {
+	client := github.NewSimpleMockClient()
+	runner := &WizardRunner{githubClient: client}
+
+	wiki := false
+	settings := &models.RepositorySettings{
+		HasWiki:    &wiki,
+		Labels:     []models.Label{{Name: "feature", Color: "a2eeef"}},
+		Milestones: []models.Milestone{{Title: "v1.0"}},
+	}
+
+	// ラベル・マイルストーン・Issue だけを同期する
+	require.NoError(t, runner.bootstrapRepository(context.Background(), "acme/billing", settings))
+	require.Len(t, client.Applied, 2)
+	assert.Equal(t, "POST repos/acme/billing/labels", client.Applied[0].String())
+	assert.Equal(t, "POST repos/acme/billing/milestones", client.Applied[1].String())
+
+	assert.Error(t, runner.bootstrapRepository(context.Background(), "billing", settings))
+	assert.Error(t, runner.bootstrapRepository(context.Background(), "acme/billing", &models.RepositorySettings{HasWiki: &wiki}))
+}
@@ -4,18 +4,40 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents application settings
 type Config struct {
-	DefaultPrivate   bool     `yaml:"default_private"`
-	DefaultClone     bool     `yaml:"default_clone"`
-	DefaultAddRemote bool     `yaml:"default_add_remote"`
-	CacheTimeout     int      `yaml:"cache_timeout"`
-	Theme            string   `yaml:"theme"`
-	RecentTemplates  []string `yaml:"recent_templates"`
+	DefaultPrivate   bool               `yaml:"default_private"`
+	DefaultClone     bool               `yaml:"default_clone"`
+	DefaultAddRemote bool               `yaml:"default_add_remote"`
+	DefaultOwner     string             `yaml:"default_owner,omitempty"`
+	CacheTimeout     int                `yaml:"cache_timeout"`
+	Theme            string             `yaml:"theme"`
+	RecentTemplates  []string           `yaml:"recent_templates"`
+	Organizations    []string           `yaml:"organizations,omitempty"`
+	LocalTemplates   []string           `yaml:"local_templates,omitempty"`
+	Registries       []string           `yaml:"registries,omitempty"`
+	MaxRepositories  int                `yaml:"max_repositories,omitempty"`
+	TemplateSort     string             `yaml:"template_sort,omitempty"`
+	GitBackend       string             `yaml:"git_backend,omitempty"`
+	Profile          string             `yaml:"profile,omitempty"`
+	Profiles         map[string]Profile `yaml:"profiles,omitempty"`
+	TrustedHooks     map[string]string  `yaml:"trusted_hooks,omitempty"`
+	// RepoSettings are applied to every GitHub repository created, over those of the template
+	RepoSettings *models.RepositorySettings `yaml:"repo_settings,omitempty"`
+	// Repository holds the defaults for repository options, under the prompts and flags
+	Repository models.RepositoryOptions `yaml:"repository,omitempty"`
+}
+
+// Profile holds template filter defaults selected by name
+type Profile struct {
+	Topics   []string `yaml:"topics,omitempty"`
+	Language string   `yaml:"language,omitempty"`
 }
 
 // GetConfigPath returns the configuration file path
@@ -87,13 +109,78 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cache timeout must be 0 or greater")
 	}
 
+	if c.MaxRepositories < 0 {
+		return fmt.Errorf("max repositories must be 0 or greater")
+	}
+
 	if c.Theme != "" && c.Theme != "default" && c.Theme != "dark" && c.Theme != "light" {
 		return fmt.Errorf("theme must be one of: 'default', 'dark', 'light'")
 	}
 
+	if c.TemplateSort != "" && c.TemplateSort != "stars" && c.TemplateSort != "updated" && c.TemplateSort != "name" {
+		return fmt.Errorf("template sort must be one of: 'stars', 'updated', 'name'")
+	}
+
+	if c.GitBackend != "" && c.GitBackend != "native" && c.GitBackend != "subprocess" {
+		return fmt.Errorf("git backend must be one of: 'native', 'subprocess'")
+	}
+
+	if _, err := c.GetProfile(""); err != nil {
+		return err
+	}
+
+	for _, path := range c.LocalTemplates {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("local template path must not be empty")
+		}
+	}
+
+	for _, registry := range c.Registries {
+		if strings.TrimSpace(registry) == "" {
+			return fmt.Errorf("registry location must not be empty")
+		}
+	}
+
+	if c.RepoSettings != nil {
+		if err := c.RepoSettings.Validate(); err != nil {
+			return fmt.Errorf("repo_settings: %w", err)
+		}
+	}
+
+	if err := c.Repository.Validate(); err != nil {
+		return fmt.Errorf("repository: %w", err)
+	}
+
+	if strings.Contains(c.DefaultOwner, "/") {
+		return fmt.Errorf("invalid default owner '%s': use a user or organization name", c.DefaultOwner)
+	}
+
+	for _, owner := range c.Organizations {
+		if strings.TrimSpace(owner) == "" || strings.Count(owner, "/") > 1 {
+			return fmt.Errorf("invalid organization '%s': use 'org' or 'org/team'", owner)
+		}
+	}
+
 	return nil
 }
 
+// GetProfile returns the named profile, or the configured default profile when name is empty.
+// No profile at all yields an empty profile.
+func (c *Config) GetProfile(name string) (Profile, error) {
+	if name == "" {
+		name = c.Profile
+	}
+	if name == "" {
+		return Profile{}, nil
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile '%s' is not defined in profiles", name)
+	}
+	return profile, nil
+}
+
 // AddRecentTemplate adds a recently used template
 func (c *Config) AddRecentTemplate(templateName string) {
 	// Remove if already exists, to add to front
@@ -112,3 +199,17 @@ func (c *Config) AddRecentTemplate(templateName string) {
 		c.RecentTemplates = c.RecentTemplates[:10]
 	}
 }
+
+// IsHookTrusted returns whether the template's hooks with the given fingerprint were trusted before
+func (c *Config) IsHookTrusted(templateName, fingerprint string) bool {
+	trusted, ok := c.TrustedHooks[templateName]
+	return ok && trusted == fingerprint
+}
+
+// TrustHooks records the template's hooks as trusted
+func (c *Config) TrustHooks(templateName, fingerprint string) {
+	if c.TrustedHooks == nil {
+		c.TrustedHooks = make(map[string]string)
+	}
+	c.TrustedHooks[templateName] = fingerprint
+}
@@ -5,7 +5,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -38,6 +41,69 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "組織とチーム",
+			config: Config{
+				Organizations: []string{"acme", "acme/platform"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "無効な並び順",
+			config: Config{
+				TemplateSort: "forks",
+			},
+			wantErr: true,
+		},
+		{
+			name: "未定義のプロファイル",
+			config: Config{
+				Profile: "backend",
+			},
+			wantErr: true,
+		},
+		{
+			name: "無効なGitバックエンド",
+			config: Config{
+				GitBackend: "libgit2",
+			},
+			wantErr: true,
+		},
+		{
+			name: "空のレジストリ",
+			config: Config{
+				Registries: []string{" "},
+			},
+			wantErr: true,
+		},
+		{
+			name: "無効なデフォルトオーナー",
+			config: Config{
+				DefaultOwner: "acme/platform",
+			},
+			wantErr: true,
+		},
+		{
+			name: "無効なマージ方法",
+			config: Config{
+				RepoSettings: &models.RepositorySettings{MergeStrategies: []string{"fast-forward"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "無効なリポジトリのトピック",
+			config: Config{
+				Repository: models.RepositoryOptions{Topics: []string{"Not A Topic"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "無効な組織名",
+			config: Config{
+				Organizations: []string{"acme/platform/extra"},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -88,6 +154,32 @@ func TestSaveAndLoad(t *testing.T) {
 	}
 }
 
+func TestConfig_GetProfile(t *testing.T) {
+	config := GetDefault()
+	config.Profile = "backend"
+	config.Profiles = map[string]Profile{
+		"backend":  {Topics: []string{"backend"}, Language: "Go"},
+		"frontend": {Topics: []string{"frontend"}},
+	}
+
+	// 名前を省略すると既定のプロファイルを使う
+	profile, err := config.GetProfile("")
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", profile.Language)
+
+	profile, err = config.GetProfile("frontend")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, profile.Topics)
+
+	_, err = config.GetProfile("unknown")
+	assert.Error(t, err)
+
+	// プロファイルがなければ空のフィルター
+	profile, err = GetDefault().GetProfile("")
+	assert.NoError(t, err)
+	assert.Equal(t, Profile{}, profile)
+}
+
 func TestConfig_AddRecentTemplate(t *testing.T) {
 	config := GetDefault()
 
@@ -119,3 +211,23 @@ func TestConfig_RecentTemplateLimit(t *testing.T) {
 	assert.Equal(t, "user/template10", config.RecentTemplates[0]) // 最新
 	assert.Equal(t, "user/template1", config.RecentTemplates[9])  // 最古（template0は削除済み）
 }
+
+func TestConfig_TrustHooks(t *testing.T) {
+	config := GetDefault()
+
+	assert.False(t, config.IsHookTrusted("acme/go-service", "abc"))
+
+	config.TrustHooks("acme/go-service", "abc")
+	assert.True(t, config.IsHookTrusted("acme/go-service", "abc"))
+
+	// フックが変わったら再確認が必要
+	assert.False(t, config.IsHookTrusted("acme/go-service", "def"))
+	assert.False(t, config.IsHookTrusted("acme/other", "abc"))
+}
+
+func TestGetConfigTemplate(t *testing.T) {
+	// テンプレートのキャッシュ設定は Config に読み込める
+	var config Config
+	require.NoError(t, yaml.Unmarshal([]byte(GetConfigTemplate()), &config))
+	assert.Equal(t, GetDefault().CacheTimeout, config.CacheTimeout)
+}
@@ -7,6 +7,7 @@ func GetDefault() *Config {
 		DefaultClone:     true,
 		DefaultAddRemote: true,
 		CacheTimeout:     30,
+		MaxRepositories:  1000,
 		Theme:            "default",
 		RecentTemplates:  make([]string, 0),
 	}
@@ -21,12 +22,63 @@ func GetConfigTemplate() string {
 default_private: true        # Make repositories private by default
 default_clone: true          # Clone locally after creation
 default_add_readme: true     # Add README file
+default_owner: ""            # User or organization new repositories are created under (empty: yourself)
 
 # Cache settings
-cache_timeout_minutes: 30    # Template list cache timeout (minutes)
+cache_timeout: 30            # Template list cache timeout (minutes)
 
 # UI settings
 theme: "default"             # Theme: default, dark, light
+template_sort: "stars"       # Template order: stars, updated, name
+
+# Git settings
+git_backend: "native"        # native (built in) or subprocess (runs the git and gh executables); update and diff always run git
+
+# Organizations (or "org/team") whose templates are listed with your own
+organizations: []
+max_repositories: 1000       # Stop listing an owner's repositories after this many
+
+# Local template directories listed with the GitHub templates
+local_templates: []
+
+# Template registries (URL, owner/repo//path/to/registry.yaml or local file) listing approved templates
+registries: []
+
+# Template filter defaults; the profile named by "profile" (or --profile) is used
+# profile: backend
+# profiles:
+#   backend:
+#     topics: [backend]
+#     language: Go
+
+# Options every GitHub repository is created with (prompts and flags add to or override them)
+# repository:
+#   visibility: internal       # public, private or internal (enterprise organizations)
+#   homepage: https://example.com
+#   topics: [backend, go]
+#   has_issues: true
+#   has_projects: false
+#   custom_properties:
+#     team: platform
+
+# Settings applied to every GitHub repository created (over the template's repo_settings)
+# repo_settings:
+#   merge_strategies: [squash]
+#   delete_branch_on_merge: true
+#   has_wiki: false
+#   branch_protection:
+#     - branch: main
+#       required_reviews: 1
+#       required_status_checks: [test]
+#   rulesets:
+#     - name: protect-main
+#       branches: [~DEFAULT_BRANCH]
+#       required_reviews: 1
+#       linear_history: true
+#   labels:
+#     - name: feature
+#       color: a2eeef
+#   remove_default_labels: true
 
 # Recently used templates (auto-updated)
 recent_templates: []
@@ -0,0 +1,248 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// defaultLabels are the labels GitHub creates in a new repository
+var defaultLabels = []string{
+	"bug", "documentation", "duplicate", "enhancement", "good first issue",
+	"help wanted", "invalid", "question", "wontfix",
+}
+
+// LabelRequest represents a request creating or updating a label
+type LabelRequest struct {
+	Name        string `json:"name,omitempty"`
+	NewName     string `json:"new_name,omitempty"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// MilestoneRequest represents a request creating a milestone
+type MilestoneRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DueOn       string `json:"due_on,omitempty"`
+}
+
+// MilestoneUpdateRequest represents a request updating a milestone; a nil due date removes it
+type MilestoneUpdateRequest struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	DueOn       *string `json:"due_on"`
+}
+
+// IssueRequest represents a request opening an issue
+type IssueRequest struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Milestone int      `json:"milestone,omitempty"`
+}
+
+// existingLabel is a label the repository already has
+type existingLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// existingMilestone is a milestone the repository already has
+type existingMilestone struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DueOn       string `json:"due_on"`
+}
+
+// existingIssue is an issue (or pull request) the repository already has
+type existingIssue struct {
+	Title       string    `json:"title"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// repositoryContents are the labels, milestones and issues of a repository
+type repositoryContents struct {
+	labels     []existingLabel
+	milestones []existingMilestone
+	issues     []existingIssue
+}
+
+// newRepositoryContents returns what a newly created repository has: only GitHub's default labels
+func newRepositoryContents() repositoryContents {
+	contents := repositoryContents{}
+	for _, name := range defaultLabels {
+		contents.labels = append(contents.labels, existingLabel{Name: name})
+	}
+	return contents
+}
+
+// syncRepositoryContents makes the repository's labels, milestones and issues match the settings.
+// Only what differs is changed, so syncing an existing repository again converges.
+func (c *DefaultClient) syncRepositoryContents(ctx context.Context, fullName string, settings *models.RepositorySettings) error {
+	labels, err := listAll[existingLabel](ctx, c, "repos/"+fullName+"/labels")
+	if err != nil {
+		return models.NewGitHubError(fmt.Sprintf("Failed to list labels of '%s'", fullName), err)
+	}
+	milestones, err := listAll[existingMilestone](ctx, c, "repos/"+fullName+"/milestones?state=all")
+	if err != nil {
+		return models.NewGitHubError(fmt.Sprintf("Failed to list milestones of '%s'", fullName), err)
+	}
+
+	requests := labelRequests(fullName, settings, labels)
+	created := milestoneRequests(fullName, settings, milestones)
+	if err := c.sendRequests(ctx, settingsFailure, append(requests, created...)); err != nil {
+		return err
+	}
+
+	if len(settings.Issues) == 0 {
+		return nil
+	}
+
+	// Issues refer to milestones by number, which new milestones only have once created
+	if len(created) > 0 {
+		milestones, err = listAll[existingMilestone](ctx, c, "repos/"+fullName+"/milestones?state=all")
+		if err != nil {
+			return models.NewGitHubError(fmt.Sprintf("Failed to list milestones of '%s'", fullName), err)
+		}
+	}
+	issues, err := listAll[existingIssue](ctx, c, "repos/"+fullName+"/issues?state=all")
+	if err != nil {
+		return models.NewGitHubError(fmt.Sprintf("Failed to list issues of '%s'", fullName), err)
+	}
+
+	return c.sendRequests(ctx, settingsFailure, issueRequests(fullName, settings, milestones, issues))
+}
+
+// labelRequests returns the calls creating missing labels, updating changed ones and removing
+// default labels that are not wanted. Label names are compared case-insensitively, as GitHub does.
+func labelRequests(fullName string, settings *models.RepositorySettings, existing []existingLabel) []APIRequest {
+	byName := make(map[string]existingLabel, len(existing))
+	for _, label := range existing {
+		byName[strings.ToLower(label.Name)] = label
+	}
+
+	var requests []APIRequest
+	wanted := make(map[string]bool, len(settings.Labels))
+	for _, label := range settings.Labels {
+		wanted[strings.ToLower(label.Name)] = true
+
+		current, ok := byName[strings.ToLower(label.Name)]
+		if !ok {
+			requests = append(requests, APIRequest{
+				Method: http.MethodPost,
+				Path:   "repos/" + fullName + "/labels",
+				Body:   LabelRequest{Name: label.Name, Color: label.GetColor(), Description: label.Description},
+			})
+			continue
+		}
+
+		if current.Name != label.Name || !strings.EqualFold(current.Color, label.GetColor()) || current.Description != label.Description {
+			requests = append(requests, APIRequest{
+				Method: http.MethodPatch,
+				Path:   "repos/" + fullName + "/labels/" + url.PathEscape(current.Name),
+				Body:   LabelRequest{NewName: label.Name, Color: label.GetColor(), Description: label.Description},
+			})
+		}
+	}
+
+	if settings.RemoveDefaultLabels {
+		for _, name := range defaultLabels {
+			current, ok := byName[name]
+			if ok && !wanted[name] {
+				requests = append(requests, APIRequest{
+					Method: http.MethodDelete,
+					Path:   "repos/" + fullName + "/labels/" + url.PathEscape(current.Name),
+				})
+			}
+		}
+	}
+
+	return requests
+}
+
+// milestoneRequests returns the calls creating missing milestones and updating changed ones
+func milestoneRequests(fullName string, settings *models.RepositorySettings, existing []existingMilestone) []APIRequest {
+	var requests []APIRequest
+	for _, milestone := range settings.Milestones {
+		current := findMilestone(existing, milestone.Title)
+		if current == nil {
+			body := MilestoneRequest{Title: milestone.Title, Description: milestone.Description, DueOn: milestone.GetDueOn()}
+			requests = append(requests, APIRequest{Method: http.MethodPost, Path: "repos/" + fullName + "/milestones", Body: body})
+			continue
+		}
+
+		// GitHub may return the due date at another time of day, so only the dates are compared
+		currentDate, _, _ := strings.Cut(current.DueOn, "T")
+		if current.Description != milestone.Description || currentDate != milestone.DueOn {
+			// A due date removed from the configuration is removed from the milestone too
+			body := MilestoneUpdateRequest{Title: milestone.Title, Description: milestone.Description}
+			if dueOn := milestone.GetDueOn(); dueOn != "" {
+				body.DueOn = &dueOn
+			}
+			requests = append(requests, APIRequest{
+				Method: http.MethodPatch,
+				Path:   fmt.Sprintf("repos/%s/milestones/%d", fullName, current.Number),
+				Body:   body,
+			})
+		}
+	}
+	return requests
+}
+
+// issueRequests returns the calls opening issues no issue of the same title exists for.
+// Existing issues are left as they are.
+func issueRequests(fullName string, settings *models.RepositorySettings, milestones []existingMilestone, existing []existingIssue) []APIRequest {
+	opened := make(map[string]bool, len(existing))
+	for _, issue := range existing {
+		if issue.PullRequest == nil {
+			opened[issue.Title] = true
+		}
+	}
+
+	var requests []APIRequest
+	for _, issue := range settings.Issues {
+		if opened[issue.Title] {
+			continue
+		}
+
+		body := IssueRequest{Title: issue.Title, Body: issue.Body, Labels: issue.Labels}
+		if milestone := findMilestone(milestones, issue.Milestone); milestone != nil {
+			body.Milestone = milestone.Number
+		}
+		requests = append(requests, APIRequest{Method: http.MethodPost, Path: "repos/" + fullName + "/issues", Body: body})
+	}
+	return requests
+}
+
+// findMilestone returns the milestone with the given title
+func findMilestone(milestones []existingMilestone, title string) *existingMilestone {
+	for i := range milestones {
+		if milestones[i].Title == title {
+			return &milestones[i]
+		}
+	}
+	return nil
+}
+
+// listAll requests every page of a list endpoint
+func listAll[T any](ctx context.Context, c *DefaultClient, path string) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		var items []T
+		if err := c.rest(ctx, http.MethodGet, pagePath(path, page), nil, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if len(items) < restPageSize {
+			return all, nil
+		}
+	}
+}
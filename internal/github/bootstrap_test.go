@@ -0,0 +1,224 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// fakeRepository はラベル・マイルストーン・Issue を保持するリポジトリの代役
+type fakeRepository struct {
+	mu         sync.Mutex
+	labels     []existingLabel
+	milestones []existingMilestone
+	issues     []map[string]interface{}
+	writes     []string
+}
+
+func (f *fakeRepository) handle(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, "/repos/acme/billing/")
+		if r.Method != http.MethodGet {
+			f.writes = append(f.writes, r.Method+" "+path)
+		}
+
+		var body map[string]interface{}
+		if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}
+		str := func(key string) string {
+			value, _ := body[key].(string)
+			return value
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "labels":
+			json.NewEncoder(w).Encode(f.labels)
+		case r.Method == http.MethodGet && path == "milestones":
+			json.NewEncoder(w).Encode(f.milestones)
+		case r.Method == http.MethodGet && path == "issues":
+			json.NewEncoder(w).Encode(f.issues)
+		case r.Method == http.MethodPost && path == "labels":
+			f.labels = append(f.labels, existingLabel{Name: str("name"), Color: str("color"), Description: str("description")})
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		case strings.HasPrefix(path, "labels/"):
+			name := strings.TrimPrefix(path, "labels/")
+			for i, label := range f.labels {
+				if label.Name != name {
+					continue
+				}
+				if r.Method == http.MethodDelete {
+					f.labels = append(f.labels[:i], f.labels[i+1:]...)
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				f.labels[i] = existingLabel{Name: str("new_name"), Color: str("color"), Description: str("description")}
+				w.Write([]byte(`{}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+		case r.Method == http.MethodPost && path == "milestones":
+			// GitHub は期日を太平洋時間の日付として保存する
+			due := strings.Replace(str("due_on"), "T00:00:00Z", "T07:00:00Z", 1)
+			f.milestones = append(f.milestones, existingMilestone{Number: len(f.milestones) + 1, Title: str("title"), Description: str("description"), DueOn: due})
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPatch && strings.HasPrefix(path, "milestones/"):
+			for i, milestone := range f.milestones {
+				if path == fmt.Sprintf("milestones/%d", milestone.Number) {
+					f.milestones[i].Description = str("description")
+					f.milestones[i].DueOn = strings.Replace(str("due_on"), "T00:00:00Z", "T07:00:00Z", 1)
+				}
+			}
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && path == "issues":
+			f.issues = append(f.issues, body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+const testBootstrapYAML = `
+labels:
+  - name: feature
+    color: "#A2EEEF"
+    description: New feature
+  - name: priority/high
+    color: b60205
+remove_default_labels: true
+milestones:
+  - title: v1.0
+    description: First release
+    due_on: 2026-12-31
+  - title: v2.0
+issues:
+  - title: Set up CI
+  - title: Write docs
+    body: Describe the API
+    labels: [feature]
+    milestone: v2.0
+`
+
+func TestDefaultClient_ApplyRepositorySettings_Bootstrap(t *testing.T) {
+	repository := &fakeRepository{
+		labels: []existingLabel{
+			{Name: "bug", Color: "d73a4a"},
+			{Name: "Feature", Color: "cccccc"},
+			{Name: "wontfix", Color: "ffffff"},
+			{Name: "team-owned", Color: "000000"},
+		},
+		milestones: []existingMilestone{{Number: 1, Title: "v1.0", Description: "old", DueOn: "2026-12-31T07:00:00Z"}},
+		issues: []map[string]interface{}{
+			{"title": "Set up CI"},
+			{"title": "Write docs", "pull_request": map[string]interface{}{}},
+		},
+	}
+	client := newRESTTestClient(t, repository.handle(t))
+
+	var settings models.RepositorySettings
+	require.NoError(t, yaml.Unmarshal([]byte(testBootstrapYAML), &settings))
+	require.NoError(t, settings.Validate())
+
+	require.NoError(t, client.ApplyRepositorySettings(context.Background(), "acme/billing", &settings))
+
+	// 差分だけを変更し、既定ラベル以外の既存ラベルは残す
+	assert.Equal(t, []string{
+		"PATCH labels/Feature",
+		"POST labels",
+		"DELETE labels/bug",
+		"DELETE labels/wontfix",
+		"PATCH milestones/1",
+		"POST milestones",
+		"POST issues",
+	}, repository.writes)
+	assert.Equal(t, []existingLabel{
+		{Name: "feature", Color: "a2eeef", Description: "New feature"},
+		{Name: "team-owned", Color: "000000"},
+		{Name: "priority/high", Color: "b60205"},
+	}, repository.labels)
+
+	// 同名の Pull Request は Issue として数えず、新しいマイルストーンの番号で作る
+	issue := repository.issues[len(repository.issues)-1]
+	assert.Equal(t, "Write docs", issue["title"])
+	assert.Equal(t, float64(2), issue["milestone"])
+	assert.Equal(t, []interface{}{"feature"}, issue["labels"])
+
+	// もう一度実行しても何も変わらない
+	repository.writes = nil
+	require.NoError(t, client.ApplyRepositorySettings(context.Background(), "acme/billing", &settings))
+	assert.Empty(t, repository.writes)
+}
+
+func TestRepositorySettingsRequests_Bootstrap(t *testing.T) {
+	var settings models.RepositorySettings
+	require.NoError(t, yaml.Unmarshal([]byte(testBootstrapYAML), &settings))
+
+	// 新しいリポジトリには GitHub の既定ラベルだけがある
+	var calls []string
+	for _, request := range RepositorySettingsRequests("acme/billing", &settings) {
+		calls = append(calls, request.String())
+	}
+	assert.Equal(t, []string{
+		"POST repos/acme/billing/labels",
+		"POST repos/acme/billing/labels",
+		"DELETE repos/acme/billing/labels/bug",
+		"DELETE repos/acme/billing/labels/documentation",
+		"DELETE repos/acme/billing/labels/duplicate",
+		"DELETE repos/acme/billing/labels/enhancement",
+		"DELETE repos/acme/billing/labels/good%20first%20issue",
+		"DELETE repos/acme/billing/labels/help%20wanted",
+		"DELETE repos/acme/billing/labels/invalid",
+		"DELETE repos/acme/billing/labels/question",
+		"DELETE repos/acme/billing/labels/wontfix",
+		"POST repos/acme/billing/milestones",
+		"POST repos/acme/billing/milestones",
+		"POST repos/acme/billing/issues",
+		"POST repos/acme/billing/issues",
+	}, calls)
+}
+
+func TestMilestoneRequests_DueOn(t *testing.T) {
+	settings := &models.RepositorySettings{Milestones: []models.Milestone{
+		{Title: "v1.0", DueOn: "2026-12-31"},
+		{Title: "v2.0"},
+		{Title: "v3.0"},
+	}}
+	existing := []existingMilestone{
+		{Number: 1, Title: "v1.0", DueOn: "2026-06-30T07:00:00Z"},
+		{Number: 2, Title: "v2.0", DueOn: "2026-12-31T07:00:00Z"},
+		{Number: 3, Title: "v3.0"},
+	}
+
+	requests := milestoneRequests("acme/billing", settings, existing)
+	require.Len(t, requests, 2)
+
+	// 期日の変更はその日付で更新する
+	assert.Equal(t, "PATCH repos/acme/billing/milestones/1", requests[0].String())
+	data, err := json.Marshal(requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title": "v1.0", "description": "", "due_on": "2026-12-31T00:00:00Z"}`, string(data))
+
+	// 設定から消した期日は null を送って消す
+	assert.Equal(t, "PATCH repos/acme/billing/milestones/2", requests[1].String())
+	data, err = json.Marshal(requests[1].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title": "v2.0", "description": "", "due_on": null}`, string(data))
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// templateCacheEntry is the on-disk format of the template list cache
+type templateCacheEntry struct {
+	Key       string            `json:"key"`
+	FetchedAt time.Time         `json:"fetched_at"`
+	Templates []models.Template `json:"templates"`
+}
+
+// templateCache stores discovered templates between runs
+type templateCache struct {
+	key     string
+	path    string
+	timeout time.Duration
+	now     func() time.Time
+}
+
+// newTemplateCache creates a cache for the given host and owners under dir
+func newTemplateCache(dir, host string, owners []string, timeout time.Duration) *templateCache {
+	key := templateCacheKey(host, owners)
+	sum := sha256.Sum256([]byte(key))
+
+	return &templateCache{
+		key:     key,
+		path:    filepath.Join(dir, fmt.Sprintf("templates-%s.json", hex.EncodeToString(sum[:8]))),
+		timeout: timeout,
+		now:     time.Now,
+	}
+}
+
+// templateCacheKey identifies a template list by host and owner list (order-insensitive)
+func templateCacheKey(host string, owners []string) string {
+	normalized := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		normalized = append(normalized, strings.ToLower(owner))
+	}
+	sort.Strings(normalized)
+
+	return strings.ToLower(host) + "|" + strings.Join(normalized, ",")
+}
+
+// defaultCacheDir returns the gh-wizard directory under the user cache dir
+func defaultCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "gh-wizard"), nil
+}
+
+// Load returns cached templates and their age if the cache is fresh.
+// A corrupted or foreign cache file is removed so the next fetch rewrites it.
+func (tc *templateCache) Load() ([]models.Template, time.Duration, bool) {
+	data, err := os.ReadFile(tc.path)
+	if err != nil {
+		return nil, 0, false
+	}
+
+	var entry templateCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != tc.key {
+		os.Remove(tc.path)
+		return nil, 0, false
+	}
+
+	age := tc.now().Sub(entry.FetchedAt)
+	if age >= tc.timeout {
+		return nil, 0, false
+	}
+
+	return entry.Templates, age, true
+}
+
+// Save writes the templates to the cache
+func (tc *templateCache) Save(templates []models.Template) error {
+	data, err := json.Marshal(templateCacheEntry{
+		Key:       tc.key,
+		FetchedAt: tc.now(),
+		Templates: templates,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(tc.path), 0755); err != nil {
+		return err
+	}
+
+	// Write atomically so a concurrent reader never sees a partial file
+	tempFile, err := os.CreateTemp(filepath.Dir(tc.path), ".templates-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), tc.path)
+}
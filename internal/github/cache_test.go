@@ -0,0 +1,122 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateCache_LoadSave(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newTemplateCache(t.TempDir(), "github.com", []string{"acme"}, 30*time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, _, ok := cache.Load()
+	assert.False(t, ok)
+
+	templates := []models.Template{{Name: "go-service", FullName: "acme/go-service", Stars: 3}}
+	require.NoError(t, cache.Save(templates))
+
+	now = now.Add(10 * time.Minute)
+	cached, age, ok := cache.Load()
+	require.True(t, ok)
+	assert.Equal(t, templates, cached)
+	assert.Equal(t, 10*time.Minute, age)
+
+	// タイムアウトを過ぎたキャッシュは使わない
+	now = now.Add(20 * time.Minute)
+	_, _, ok = cache.Load()
+	assert.False(t, ok)
+}
+
+func TestTemplateCache_Key(t *testing.T) {
+	dir := t.TempDir()
+
+	// 組織の順序や大文字小文字は区別しない
+	a := newTemplateCache(dir, "github.com", []string{"acme", "Other"}, time.Hour)
+	b := newTemplateCache(dir, "github.com", []string{"other", "acme"}, time.Hour)
+	assert.Equal(t, a.path, b.path)
+
+	// ホストが違えば別のキャッシュ
+	c := newTemplateCache(dir, "ghe.example.com", []string{"acme", "other"}, time.Hour)
+	assert.NotEqual(t, a.path, c.path)
+}
+
+func TestTemplateCache_Corrupted(t *testing.T) {
+	cache := newTemplateCache(t.TempDir(), "github.com", nil, time.Hour)
+	require.NoError(t, os.WriteFile(cache.path, []byte("{not json"), 0644))
+
+	// 壊れたキャッシュは削除して無視する
+	_, _, ok := cache.Load()
+	assert.False(t, ok)
+	assert.NoFileExists(t, cache.path)
+}
+
+func TestDefaultClient_SearchPopularTemplates_Cache(t *testing.T) {
+	var calls, stars atomic.Int32
+	stars.Store(1)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	options := ClientOptions{CacheTimeout: time.Hour, CacheDir: t.TempDir()}
+	server := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		response := `[{"name": "go-service", "full_name": "me/go-service", "owner": {"login": "me"}, "stargazers_count": %d, "is_template": true}]`
+		fmt.Fprintf(w, response, stars.Load())
+	})
+	newClient := func(options ClientOptions) *DefaultClient {
+		client := NewClientWithOptions(options).(*DefaultClient)
+		client.cache.now = func() time.Time { return now }
+		client.apiURL = server.apiURL
+		return client
+	}
+
+	// 初回は取得してキャッシュに保存する
+	client := newClient(options)
+	templates, err := client.SearchPopularTemplates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, templates[0].Stars)
+	assert.EqualValues(t, 1, calls.Load())
+
+	// 新しいキャッシュはそのまま返し、更新もしない
+	stars.Store(2)
+	now = now.Add(10 * time.Minute)
+	client = newClient(options)
+	templates, err = client.SearchPopularTemplates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, templates[0].Stars)
+	assert.True(t, client.Wait(time.Second))
+	assert.EqualValues(t, 1, calls.Load())
+
+	// タイムアウトの半分を過ぎたキャッシュは返しつつ、バックグラウンドで更新する
+	now = now.Add(30 * time.Minute)
+	client = newClient(options)
+	templates, err = client.SearchPopularTemplates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, templates[0].Stars)
+	assert.True(t, client.Wait(time.Second))
+	assert.EqualValues(t, 2, calls.Load())
+
+	// 次の実行ではバックグラウンド更新の結果が使われる
+	client = newClient(options)
+	templates, err = client.SearchPopularTemplates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, templates[0].Stars)
+	assert.True(t, client.Wait(time.Second))
+	assert.EqualValues(t, 2, calls.Load())
+
+	// --refresh はキャッシュを使わない
+	stars.Store(3)
+	options.Refresh = true
+	client = newClient(options)
+	templates, err = client.SearchPopularTemplates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, templates[0].Stars)
+}
@@ -2,12 +2,19 @@ package github
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/base64"
 	"fmt"
-	"os/exec"
+	"io"
+	"net/http"
+	"os"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/cli/go-gh/v2/pkg/auth"
 )
 
 type Client interface {
@@ -17,85 +24,377 @@ type Client interface {
 	// SearchPopularTemplates searches for popular template repositories
 	SearchPopularTemplates(ctx context.Context) ([]models.Template, error)
 
-	// CreateRepository creates a GitHub repository
-	CreateRepository(ctx context.Context, config *models.ProjectConfig) error
+	// SearchTemplates searches public template repositories on GitHub matching the keywords
+	SearchTemplates(ctx context.Context, keywords string) ([]models.Template, error)
+
+	// CreateRepository creates a GitHub repository for the project
+	CreateRepository(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error)
+
+	// GenerateRepository creates the project's GitHub repository from its template on GitHub
+	GenerateRepository(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error)
+
+	// ListRepositoryOwners lists the authenticated user followed by the organizations they can create repositories in
+	ListRepositoryOwners(ctx context.Context) ([]string, error)
+
+	// GrantTeamAccess gives an organization team access to a repository
+	GrantTeamAccess(ctx context.Context, fullName string, grant models.TeamGrant) error
+
+	// ApplyRepositorySettings applies merge, feature, branch protection and ruleset settings to a repository
+	ApplyRepositorySettings(ctx context.Context, fullName string, settings *models.RepositorySettings) error
 
 	// CheckAuthentication checks authentication status
 	CheckAuthentication(ctx context.Context) error
+
+	// GetFileContent gets a file from a repository (nil if the file does not exist)
+	GetFileContent(ctx context.Context, fullName, path string) ([]byte, error)
+
+	// Wait waits up to timeout for background work, such as refreshing the template cache, to finish
+	Wait(timeout time.Duration) bool
+}
+
+// defaultMaxRepositories caps how many repositories are listed per owner
+const defaultMaxRepositories = 1000
+
+// restPageSize is the page size used when listing through the REST API (the largest GitHub allows)
+const restPageSize = 100
+
+// backgroundRefreshTimeout bounds a background refresh of the template cache
+const backgroundRefreshTimeout = 2 * time.Minute
+
+// cacheRefreshFraction is how old a cached template list gets, as a fraction of the cache timeout,
+// before it is refreshed in the background
+const cacheRefreshFraction = 0.5
+
+// ClientOptions configures template discovery
+type ClientOptions struct {
+	// Owners are organizations (or "org/team") searched in addition to the authenticated user
+	Owners []string
+	// MaxRepositories caps how many repositories are listed per owner (0 uses the default)
+	MaxRepositories int
+	// CacheTimeout is how long a discovered template list is reused (0 disables the cache)
+	CacheTimeout time.Duration
+	// CacheDir overrides the cache directory (defaults to gh-wizard under the user cache dir)
+	CacheDir string
+	// Refresh ignores the cached template list for this run
+	Refresh bool
 }
 
 // DefaultClient is the default implementation using go-gh
 type DefaultClient struct {
-	// go-gh client is managed internally
+	owners          []string
+	maxRepositories int
+	cache           *templateCache
+	refresh         bool
+	warnings        io.Writer
+	background      sync.WaitGroup
+	// apiURL overrides the REST API base URL of the authenticated host
+	apiURL string
+	// generatePollInterval and generateTimeout control waiting for a generated repository (0 uses the defaults)
+	generatePollInterval time.Duration
+	generateTimeout      time.Duration
 }
 
 // NewClient creates a new GitHub client
 func NewClient() Client {
-	return &DefaultClient{}
+	return NewClientWithOptions(ClientOptions{})
 }
 
-// GetUserTemplates gets user's template repositories
+// NewClientWithOptions creates a new GitHub client with discovery options
+func NewClientWithOptions(options ClientOptions) Client {
+	maxRepositories := options.MaxRepositories
+	if maxRepositories <= 0 {
+		maxRepositories = defaultMaxRepositories
+	}
+
+	client := &DefaultClient{
+		owners:          options.Owners,
+		maxRepositories: maxRepositories,
+		refresh:         options.Refresh,
+		warnings:        os.Stdout,
+	}
+
+	if options.CacheTimeout > 0 {
+		cacheDir := options.CacheDir
+		if cacheDir == "" {
+			cacheDir, _ = defaultCacheDir()
+		}
+		if cacheDir != "" {
+			host, _ := auth.DefaultHost()
+			client.cache = newTemplateCache(cacheDir, host, options.Owners, options.CacheTimeout)
+		}
+	}
+
+	return client
+}
+
+// GetUserTemplates gets the template repositories owned by the authenticated user
 func (c *DefaultClient) GetUserTemplates(ctx context.Context) ([]models.Template, error) {
-	// TODO: Implementation planned in Issue #28
-	// Return empty slice for testing
-	return []models.Template{}, nil
+	return c.userTemplates(ctx, c.getWarnings())
+}
+
+// userTemplates lists the template repositories owned by the authenticated user
+func (c *DefaultClient) userTemplates(ctx context.Context, warnings io.Writer) ([]models.Template, error) {
+	templates, err := c.listOwnerTemplates(ctx, "", warnings)
+	if err != nil {
+		return nil, models.NewGitHubError("Failed to get user repositories", err)
+	}
+	return templates, nil
 }
 
-// SearchPopularTemplates gets user's own template repositories
+// SearchPopularTemplates gets template repositories of the authenticated user and the configured owners.
+// A fresh cached list is returned immediately; once it is halfway to expiring, it is refreshed in the
+// background for the next run.
 func (c *DefaultClient) SearchPopularTemplates(ctx context.Context) ([]models.Template, error) {
-	// Get only authenticated user's repositories
-	cmd := exec.CommandContext(ctx, "gh", "repo", "list", "--json", "name,owner,stargazerCount,description,isTemplate", "--limit", "100")
+	if c.cache != nil && !c.refresh {
+		if templates, age, ok := c.cache.Load(); ok {
+			if age >= time.Duration(float64(c.cache.timeout)*cacheRefreshFraction) {
+				c.refreshCacheInBackground()
+			}
+			return templates, nil
+		}
+	}
+
+	templates, err := c.fetchTemplates(ctx, c.getWarnings())
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cache != nil {
+		// The cache is an optimization; failing to write it is not an error
+		_ = c.cache.Save(templates)
+	}
+
+	return templates, nil
+}
+
+// refreshCacheInBackground fetches the template list without blocking and stores it in the cache
+func (c *DefaultClient) refreshCacheInBackground() {
+	c.background.Add(1)
+	go func() {
+		defer c.background.Done()
 
-	output, err := cmd.Output()
+		ctx, cancel := context.WithTimeout(context.Background(), backgroundRefreshTimeout)
+		defer cancel()
+
+		// Warnings would interleave with the interactive prompts
+		templates, err := c.fetchTemplates(ctx, io.Discard)
+		if err != nil {
+			return
+		}
+		_ = c.cache.Save(templates)
+	}()
+}
+
+// Wait waits up to timeout for background refreshes to finish and reports whether they did
+func (c *DefaultClient) Wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		c.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// fetchTemplates lists templates of the authenticated user and the configured owners
+func (c *DefaultClient) fetchTemplates(ctx context.Context, warnings io.Writer) ([]models.Template, error) {
+	// Get authenticated user's repositories
+	templates, err := c.userTemplates(ctx, warnings)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get user repositories: %w", err)
+		return nil, err
 	}
 
-	var repositories []struct {
-		Name  string `json:"name"`
-		Owner struct {
-			Login string `json:"login"`
-		} `json:"owner"`
-		StargazerCount int    `json:"stargazerCount"`
-		Description    string `json:"description"`
-		IsTemplate     bool   `json:"isTemplate"`
+	lists := [][]models.Template{templates}
+	for _, owner := range c.owners {
+		ownerTemplates, err := c.listOwnerTemplates(ctx, owner, warnings)
+		if err != nil {
+			// An unreachable organization should not hide the other templates
+			fmt.Fprintf(warnings, "⚠️  Failed to get templates of '%s': %v\n", owner, err)
+			continue
+		}
+		lists = append(lists, ownerTemplates)
 	}
 
-	if err := json.Unmarshal(output, &repositories); err != nil {
-		return nil, fmt.Errorf("failed to parse repository list: %w", err)
+	return c.expandCollections(ctx, MergeTemplates(lists...)), nil
+}
+
+// getWarnings returns where discovery warnings are written
+func (c *DefaultClient) getWarnings() io.Writer {
+	if c.warnings == nil {
+		return os.Stdout
 	}
+	return c.warnings
+}
 
-	// Filter only template repositories
-	var templates []models.Template
+// listOwnerTemplates lists template repositories of the authenticated user (empty owner),
+// an organization or user, or an "org/team"
+func (c *DefaultClient) listOwnerTemplates(ctx context.Context, owner string, warnings io.Writer) ([]models.Template, error) {
+	if org, team, ok := strings.Cut(owner, "/"); ok {
+		return c.listTemplates(ctx, fmt.Sprintf("orgs/%s/teams/%s/repos", org, team), owner, warnings)
+	}
+	if owner == "" {
+		return c.listTemplates(ctx, "user/repos?affiliation=owner&sort=updated", owner, warnings)
+	}
+
+	// Owners are usually organizations; fall back to a user's public repositories
+	templates, err := c.listTemplates(ctx, fmt.Sprintf("orgs/%s/repos?type=all&sort=updated", owner), owner, warnings)
+	if isNotFound(err) {
+		return c.listTemplates(ctx, fmt.Sprintf("users/%s/repos?sort=updated", owner), owner, warnings)
+	}
+	return templates, err
+}
+
+// listTemplates lists the template repositories of a REST repository list, page by page up to the repository limit
+func (c *DefaultClient) listTemplates(ctx context.Context, path, owner string, warnings io.Writer) ([]models.Template, error) {
+	limit := c.getMaxRepositories()
+	var repositories []restRepository
+	for page := 1; len(repositories) < limit; page++ {
+		var pageRepositories []restRepository
+		if err := c.rest(ctx, http.MethodGet, pagePath(path, page), nil, &pageRepositories); err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, pageRepositories...)
+
+		if len(pageRepositories) < restPageSize {
+			break
+		}
+	}
+	if len(repositories) >= limit {
+		repositories = repositories[:limit]
+		warnRepositoryLimit(warnings, owner, limit)
+	}
+
+	templates := []models.Template{}
 	for _, repo := range repositories {
 		if repo.IsTemplate {
-			templates = append(templates, models.Template{
-				Name:        repo.Name,
-				FullName:    fmt.Sprintf("%s/%s", repo.Owner.Login, repo.Name),
-				Stars:       repo.StargazerCount,
-				Description: repo.Description,
-			})
+			templates = append(templates, repo.toTemplate())
+		}
+	}
+
+	return templates, nil
+}
+
+// pagePath adds the page size and page number to the query of a REST list path
+func pagePath(path string, page int) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%sper_page=%d&page=%d", path, separator, restPageSize, page)
+}
+
+// restRepository is a repository as returned by the REST API
+type restRepository struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	Owner    struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Description     string    `json:"description"`
+	StargazersCount int       `json:"stargazers_count"`
+	ForksCount      int       `json:"forks_count"`
+	Language        string    `json:"language"`
+	Topics          []string  `json:"topics"`
+	IsTemplate      bool      `json:"is_template"`
+	Private         bool      `json:"private"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	CloneURL        string    `json:"clone_url"`
+}
+
+// toTemplate converts the repository to a template
+func (r restRepository) toTemplate() models.Template {
+	topics := r.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+
+	return models.Template{
+		ID:          strconv.FormatInt(r.ID, 10),
+		Name:        r.Name,
+		FullName:    r.FullName,
+		Owner:       r.Owner.Login,
+		Description: r.Description,
+		Stars:       r.StargazersCount,
+		Forks:       r.ForksCount,
+		Language:    r.Language,
+		Topics:      topics,
+		IsTemplate:  r.IsTemplate,
+		Private:     r.Private,
+		UpdatedAt:   r.UpdatedAt,
+		CloneURL:    r.CloneURL,
+	}
+}
+
+// getMaxRepositories returns the per-owner repository cap
+func (c *DefaultClient) getMaxRepositories() int {
+	if c.maxRepositories <= 0 {
+		return defaultMaxRepositories
+	}
+	return c.maxRepositories
+}
+
+// warnRepositoryLimit reports that listing stopped at the safety cap
+func warnRepositoryLimit(warnings io.Writer, owner string, limit int) {
+	if owner == "" {
+		owner = "your account"
+	}
+	fmt.Fprintf(warnings, "⚠️  Stopped listing %s after %d repositories. Raise max_repositories in the configuration to see more.\n", owner, limit)
+}
+
+// MergeTemplates merges template lists, dropping duplicates by reference, sorted by star count
+func MergeTemplates(lists ...[]models.Template) []models.Template {
+	seen := make(map[string]bool)
+	var templates []models.Template
+	for _, list := range lists {
+		for _, template := range list {
+			// Repository names are case-insensitive on GitHub
+			key := strings.ToLower(template.GetReference())
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			templates = append(templates, template)
 		}
 	}
 
 	// Sort by star count
-	sort.Slice(templates, func(i, j int) bool {
+	sort.SliceStable(templates, func(i, j int) bool {
 		return templates[i].Stars > templates[j].Stars
 	})
 
-	return templates, nil
+	return templates
 }
 
-// CreateRepository creates a GitHub repository
-func (c *DefaultClient) CreateRepository(ctx context.Context, config *models.ProjectConfig) error {
-	// TODO: Implementation planned in Issue #28
-	return nil
+// GetFileContent gets a file from a repository (nil if the file does not exist)
+func (c *DefaultClient) GetFileContent(ctx context.Context, fullName, path string) ([]byte, error) {
+	var content restContent
+	if err := c.rest(ctx, http.MethodGet, fmt.Sprintf("repos/%s/contents/%s", fullName, path), nil, &content); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s from %s: %w", path, fullName, err)
+	}
+
+	if content.Encoding != "base64" {
+		return nil, fmt.Errorf("failed to get %s from %s: unsupported encoding '%s'", path, fullName, content.Encoding)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s from %s: %w", path, fullName, err)
+	}
+	return data, nil
 }
 
-// CheckAuthentication checks authentication status
-func (c *DefaultClient) CheckAuthentication(ctx context.Context) error {
-	// TODO: Implementation planned in Issue #28
-	return nil
+// restContent is a file from the repository contents API
+type restContent struct {
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
 }
 
 // GetTemplateByFullName searches for template by full name
@@ -135,3 +434,26 @@ func SortTemplatesByUpdated(templates []models.Template) {
 		return templates[i].UpdatedAt.After(templates[j].UpdatedAt)
 	})
 }
+
+// SortTemplatesByName sorts templates by name, then by owner
+func SortTemplatesByName(templates []models.Template) {
+	sort.SliceStable(templates, func(i, j int) bool {
+		left, right := strings.ToLower(templates[i].Name), strings.ToLower(templates[j].Name)
+		if left != right {
+			return left < right
+		}
+		return strings.ToLower(templates[i].FullName) < strings.ToLower(templates[j].FullName)
+	})
+}
+
+// SortTemplates sorts templates in the given order
+func SortTemplates(templates []models.Template, order models.TemplateSortOrder) {
+	switch order {
+	case models.SortByUpdated:
+		SortTemplatesByUpdated(templates)
+	case models.SortByName:
+		SortTemplatesByName(templates)
+	default:
+		SortTemplatesByStars(templates)
+	}
+}
@@ -2,7 +2,13 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,9 +33,16 @@ func (m *MockClient) GetUserTemplates(ctx context.Context) ([]models.Template, e
 	return args.Get(0).([]models.Template), args.Error(1)
 }
 
-func (m *MockClient) CreateRepository(ctx context.Context, config *models.ProjectConfig) error {
+func (m *MockClient) CreateRepository(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error) {
 	args := m.Called(ctx, config)
-	return args.Error(0)
+	info, _ := args.Get(0).(*RepositoryInfo)
+	return info, args.Error(1)
+}
+
+func (m *MockClient) GenerateRepository(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error) {
+	args := m.Called(ctx, config)
+	info, _ := args.Get(0).(*RepositoryInfo)
+	return info, args.Error(1)
 }
 
 func TestMockClient_GetUserTemplates_Success(t *testing.T) {
@@ -164,10 +177,271 @@ func TestMockClient_CreateRepository(t *testing.T) {
 		IsPrivate:    true,
 	}
 
-	mockClient.On("CreateRepository", ctx, config).Return(nil)
+	mockClient.On("CreateRepository", ctx, config).Return(&RepositoryInfo{Name: "test-project"}, nil)
 
-	err := mockClient.CreateRepository(ctx, config)
+	info, err := mockClient.CreateRepository(ctx, config)
 
 	assert.NoError(t, err)
+	assert.Equal(t, "test-project", info.Name)
 	mockClient.AssertExpectations(t)
 }
+
+// newListingTestClient はパスとクエリごとの応答を返す REST クライアントを作り、要求されたパスを記録する
+func newListingTestClient(t *testing.T, responses map[string]string) (*DefaultClient, *[]string) {
+	var requested []string
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		uri := strings.TrimPrefix(r.URL.RequestURI(), "/")
+		requested = append(requested, uri)
+
+		response, ok := responses[uri]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	})
+	return client, &requested
+}
+
+func TestDefaultClient_SearchPopularTemplates_Owners(t *testing.T) {
+	client, _ := newListingTestClient(t, map[string]string{
+		"user/repos?affiliation=owner&sort=updated&per_page=100&page=1": `[
+			{"name": "go-service", "full_name": "me/go-service", "owner": {"login": "me"}, "stargazers_count": 1, "is_template": true},
+			{"name": "dotfiles", "full_name": "me/dotfiles", "owner": {"login": "me"}, "is_template": false}
+		]`,
+		"orgs/acme/repos?type=all&sort=updated&per_page=100&page=1": `[
+			{"name": "go-service", "full_name": "acme/go-service", "owner": {"login": "acme"}, "stargazers_count": 20, "is_template": true}
+		]`,
+		"orgs/acme/teams/platform/repos?per_page=100&page=1": `[
+			{"name": "go-service", "full_name": "acme/go-service", "owner": {"login": "acme"}, "is_template": true},
+			{"name": "infra-module", "full_name": "acme/infra-module", "owner": {"login": "acme"}, "stargazers_count": 5, "is_template": true}
+		]`,
+		"users/octocat/repos?sort=updated&per_page=100&page=1": `[
+			{"name": "hello-template", "full_name": "octocat/hello-template", "owner": {"login": "octocat"}, "stargazers_count": 3, "is_template": true}
+		]`,
+	})
+	client.owners = []string{"acme", "acme/platform", "octocat", "unreachable"}
+	client.warnings = io.Discard
+
+	templates, err := client.SearchPopularTemplates(context.Background())
+	require.NoError(t, err)
+
+	// FullName で重複を除き、スター数順に並べる。組織でなければユーザーとして探し、取得できない所有者は無視する
+	var fullNames []string
+	for _, template := range templates {
+		fullNames = append(fullNames, template.FullName)
+	}
+	assert.Equal(t, []string{"acme/go-service", "acme/infra-module", "octocat/hello-template", "me/go-service"}, fullNames)
+	assert.Equal(t, "acme", templates[0].Owner)
+}
+
+func TestDefaultClient_SearchPopularTemplates_UserError(t *testing.T) {
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := client.SearchPopularTemplates(context.Background())
+	assert.Error(t, err)
+
+	_, err = client.GetUserTemplates(context.Background())
+	assert.Error(t, err)
+}
+
+func TestDefaultClient_TemplatesPagination(t *testing.T) {
+	page := func(start, count int) string {
+		var repos []string
+		for i := start; i < start+count; i++ {
+			repos = append(repos, fmt.Sprintf(`{"name": "repo-%d", "full_name": "acme/repo-%d", "is_template": %t}`, i, i, i%2 == 0))
+		}
+		return "[" + strings.Join(repos, ",") + "]"
+	}
+	responses := map[string]string{
+		"orgs/acme/teams/platform/repos?per_page=100&page=1":            page(0, 100),
+		"orgs/acme/teams/platform/repos?per_page=100&page=2":            page(100, 100),
+		"orgs/acme/teams/platform/repos?per_page=100&page=3":            page(200, 30),
+		"user/repos?affiliation=owner&sort=updated&per_page=100&page=1": page(0, 100),
+		"user/repos?affiliation=owner&sort=updated&per_page=100&page=2": page(100, 100),
+		"user/repos?affiliation=owner&sort=updated&per_page=100&page=3": page(200, 30),
+	}
+
+	// 100 件を超えても全ページを取得する
+	client, requested := newListingTestClient(t, responses)
+	templates, err := client.listOwnerTemplates(context.Background(), "acme/platform", io.Discard)
+	require.NoError(t, err)
+	assert.Len(t, templates, 115)
+	assert.Len(t, *requested, 3)
+
+	// 上限に達したら取得を止めて警告する
+	client, requested = newListingTestClient(t, responses)
+	client.maxRepositories = 150
+	var warnings strings.Builder
+	templates, err = client.listOwnerTemplates(context.Background(), "", &warnings)
+	require.NoError(t, err)
+	assert.Len(t, templates, 75)
+	assert.Len(t, *requested, 2)
+	assert.Contains(t, warnings.String(), "after 150 repositories")
+}
+
+func TestNewClientWithOptions_RepositoryLimit(t *testing.T) {
+	client := NewClientWithOptions(ClientOptions{MaxRepositories: 250}).(*DefaultClient)
+	assert.Equal(t, 250, client.getMaxRepositories())
+
+	client = NewClientWithOptions(ClientOptions{}).(*DefaultClient)
+	assert.Equal(t, defaultMaxRepositories, client.getMaxRepositories())
+}
+
+func TestDefaultClient_TemplateFields(t *testing.T) {
+	client, _ := newListingTestClient(t, map[string]string{
+		"user/repos?affiliation=owner&sort=updated&per_page=100&page=1": `[{
+			"id": 42, "name": "infra-module", "full_name": "acme/infra-module", "owner": {"login": "acme"},
+			"description": "Terraform module", "stargazers_count": 5, "forks_count": 2, "language": "HCL",
+			"topics": ["terraform"], "is_template": true, "private": true,
+			"updated_at": "2024-03-01T00:00:00Z", "clone_url": "https://github.com/acme/infra-module.git"
+		}, {
+			"id": 43, "name": "empty", "full_name": "me/empty", "owner": {"login": "me"}, "language": null,
+			"topics": null, "is_template": true
+		}]`,
+	})
+
+	templates, err := client.GetUserTemplates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+
+	// REST API の結果から全フィールドを埋める
+	assert.Equal(t, models.Template{
+		ID:          "42",
+		Name:        "infra-module",
+		FullName:    "acme/infra-module",
+		Owner:       "acme",
+		Description: "Terraform module",
+		Stars:       5,
+		Forks:       2,
+		Language:    "HCL",
+		Topics:      []string{"terraform"},
+		IsTemplate:  true,
+		Private:     true,
+		UpdatedAt:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		CloneURL:    "https://github.com/acme/infra-module.git",
+	}, templates[0])
+
+	// 言語やトピックがなくても空のまま扱える
+	assert.Equal(t, "", templates[1].Language)
+	assert.Equal(t, []string{}, templates[1].Topics)
+}
+
+func TestSortTemplates(t *testing.T) {
+	newTemplates := func() []models.Template {
+		return []models.Template{
+			{Name: "beta", FullName: "me/beta", Stars: 5, UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "Alpha", FullName: "me/Alpha", Stars: 1, UpdatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "gamma", FullName: "me/gamma", Stars: 9, UpdatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		}
+	}
+	names := func(templates []models.Template) []string {
+		var result []string
+		for _, template := range templates {
+			result = append(result, template.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		order    models.TemplateSortOrder
+		expected []string
+	}{
+		{models.SortByStars, []string{"gamma", "beta", "Alpha"}},
+		{models.SortByUpdated, []string{"Alpha", "beta", "gamma"}},
+		{models.SortByName, []string{"Alpha", "beta", "gamma"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			templates := newTemplates()
+			SortTemplates(templates, tt.order)
+			assert.Equal(t, tt.expected, names(templates))
+		})
+	}
+}
+
+// writeContent はリポジトリのコンテンツ API と同じ形でファイルを返す
+func writeContent(w http.ResponseWriter, content string) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"content": %q, "encoding": "base64"}`, base64.StdEncoding.EncodeToString([]byte(content)))
+}
+
+func TestDefaultClient_GetFileContent(t *testing.T) {
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/templates/contents/"+models.ManifestFileName {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		writeContent(w, "templates:\n  - path: web\n")
+	})
+
+	data, err := client.GetFileContent(context.Background(), "acme/templates", models.ManifestFileName)
+	require.NoError(t, err)
+	assert.Equal(t, "templates:\n  - path: web\n", string(data))
+
+	// 存在しないファイルは nil でエラーにしない
+	data, err = client.GetFileContent(context.Background(), "acme/other", models.ManifestFileName)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestDefaultClient_ExpandsCollections(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/user/repos" {
+			w.Write([]byte(`[
+				{"name": "templates", "full_name": "acme/templates", "owner": {"login": "acme"}, "stargazers_count": 30, "is_template": true, "topics": ["gh-wizard-collection"]},
+				{"name": "rust-cli", "full_name": "acme/rust-cli", "owner": {"login": "acme"}, "stargazers_count": 20, "is_template": true, "topics": ["gh-wizard-collection"]},
+				{"name": "broken", "full_name": "acme/broken", "owner": {"login": "acme"}, "stargazers_count": 10, "is_template": true, "topics": ["gh-wizard-collection"]},
+				{"name": "go-template", "full_name": "acme/go-template", "owner": {"login": "acme"}, "stargazers_count": 5, "is_template": true}
+			]`))
+			return
+		}
+
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/repos/acme/templates/contents/" + models.ManifestFileName:
+			writeContent(w, "templates:\n  - name: go-service\n    path: services/go\n    description: Go microservice\n  - path: web\n")
+		case "/repos/acme/rust-cli/contents/" + models.ManifestFileName:
+			writeContent(w, "variables:\n  - name: use_ci\n    type: bool\n")
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	templates, err := client.SearchPopularTemplates(context.Background())
+	require.NoError(t, err)
+
+	// コレクションはサブテンプレートに置き換え、それ以外はそのまま残す
+	var references []string
+	for _, template := range templates {
+		references = append(references, template.GetReference())
+	}
+	assert.Equal(t, []string{
+		"acme/templates//services/go",
+		"acme/templates//web",
+		"acme/rust-cli",
+		"acme/broken",
+		"acme/go-template",
+	}, references)
+	assert.Equal(t, "go-service", templates[0].Name)
+	assert.Equal(t, "Go microservice", templates[0].Description)
+	assert.Equal(t, 30, templates[1].Stars)
+
+	// マニフェストはトピックで宣言したリポジトリからだけ読む
+	assert.ElementsMatch(t, []string{
+		"/repos/acme/templates/contents/" + models.ManifestFileName,
+		"/repos/acme/rust-cli/contents/" + models.ManifestFileName,
+		"/repos/acme/broken/contents/" + models.ManifestFileName,
+	}, requested)
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// CollectionTopic marks a repository whose manifest declares sub-templates. Only repositories with
+// this topic are expanded, so discovery does not read the manifest of every repository.
+const CollectionTopic = "gh-wizard-collection"
+
+// collectionWorkers bounds the manifest requests made at once while expanding collections
+const collectionWorkers = 8
+
+// expandCollections replaces each collection repository, whose manifest declares sub-templates,
+// with those sub-templates. Repositories whose manifest cannot be read are kept as they are.
+func (c *DefaultClient) expandCollections(ctx context.Context, templates []models.Template) []models.Template {
+	expanded := make([][]models.Template, len(templates))
+	semaphore := make(chan struct{}, collectionWorkers)
+
+	var wg sync.WaitGroup
+	for i, template := range templates {
+		if !template.HasTopic(CollectionTopic) {
+			expanded[i] = []models.Template{template}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			expanded[i] = c.collectionTemplates(ctx, template)
+		}()
+	}
+	wg.Wait()
+
+	var result []models.Template
+	for _, list := range expanded {
+		result = append(result, list...)
+	}
+	return result
+}
+
+// collectionTemplates returns the sub-templates declared by the repository's manifest,
+// or the repository itself when it is not a collection
+func (c *DefaultClient) collectionTemplates(ctx context.Context, template models.Template) []models.Template {
+	data, err := c.GetFileContent(ctx, template.FullName, models.ManifestFileName)
+	if err != nil || data == nil {
+		return []models.Template{template}
+	}
+
+	manifest, err := models.ParseManifest(data)
+	if err != nil || len(manifest.Templates) == 0 {
+		return []models.Template{template}
+	}
+
+	return template.SubTemplates(manifest.Templates)
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Defaults for waiting until a generated repository has its contents
+const (
+	defaultGeneratePollInterval = time.Second
+	defaultGenerateTimeout      = time.Minute
+)
+
+// GenerateRepositoryRequest represents a request to the template generate endpoint
+type GenerateRepositoryRequest struct {
+	Owner              string `json:"owner,omitempty"`
+	Name               string `json:"name"`
+	Description        string `json:"description,omitempty"`
+	IncludeAllBranches bool   `json:"include_all_branches"`
+	Private            bool   `json:"private"`
+}
+
+// GenerateRepository creates the project's repository on GitHub from its template repository and
+// waits until the generated contents can be cloned. The repository is created under config.Owner,
+// or the authenticated user when it is empty.
+func (c *DefaultClient) GenerateRepository(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error) {
+	if config.Template == nil || config.Template.FullName == "" {
+		return nil, models.NewValidationError("Generating a repository requires a GitHub template")
+	}
+
+	owner := config.Owner
+	if owner == "" {
+		user, err := c.getCurrentUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		owner = user.Login
+	}
+
+	if err := c.checkRepositoryExists(ctx, owner, config.Name); err != nil {
+		return nil, err
+	}
+
+	// Record the template commit the repository is generated from so the project can be updated later
+	var head struct {
+		SHA string `json:"sha"`
+	}
+	if err := c.rest(ctx, http.MethodGet, fmt.Sprintf("repos/%s/commits/HEAD", config.Template.FullName), nil, &head); err != nil {
+		return nil, models.NewGitHubError(fmt.Sprintf("Failed to read template repository '%s'", config.Template.FullName), err)
+	}
+
+	request := GenerateRepositoryRequest{
+		Owner:              owner,
+		Name:               config.Name,
+		Description:        config.Description,
+		IncludeAllBranches: config.IncludeAllBranches,
+		Private:            config.IsPrivate,
+	}
+
+	var repoInfo RepositoryInfo
+	if err := c.rest(ctx, http.MethodPost, fmt.Sprintf("repos/%s/generate", config.Template.FullName), request, &repoInfo); err != nil {
+		return nil, models.NewGitHubError(
+			fmt.Sprintf("Failed to generate repository '%s/%s' from template '%s'", owner, config.Name, config.Template.FullName),
+			err,
+		)
+	}
+	repoInfo.TemplateCommit = head.SHA
+
+	if err := c.waitUntilGenerated(ctx, repoInfo.FullName); err != nil {
+		return nil, err
+	}
+
+	// The generate endpoint only takes the name, description and privacy
+	if err := c.sendRequests(ctx, optionsFailure, RepositoryOptionRequests(repoInfo.FullName, config.Repository)); err != nil {
+		return nil, err
+	}
+	return &repoInfo, nil
+}
+
+// waitUntilGenerated polls the generated repository until GitHub has copied the template's commits into it
+func (c *DefaultClient) waitUntilGenerated(ctx context.Context, fullName string) error {
+	interval := c.generatePollInterval
+	if interval <= 0 {
+		interval = defaultGeneratePollInterval
+	}
+	timeout := c.generateTimeout
+	if timeout <= 0 {
+		timeout = defaultGenerateTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		var commits []struct {
+			SHA string `json:"sha"`
+		}
+		err := c.rest(ctx, http.MethodGet, fmt.Sprintf("repos/%s/commits?per_page=1", fullName), nil, &commits)
+		if err == nil && len(commits) > 0 {
+			return nil
+		}
+		// The repository answers 404 until it exists and 409 while it is still empty
+		if err != nil && ctx.Err() == nil && !isNotFound(err) && !isConflict(err) {
+			return models.NewGitHubError(fmt.Sprintf("Failed to check generated repository '%s'", fullName), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return models.NewGitHubError(
+				fmt.Sprintf("Generated repository '%s' was not ready after %s", fullName, timeout),
+				ctx.Err(),
+			)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// isConflict reports whether a REST error is a 409 response
+func isConflict(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict
+}
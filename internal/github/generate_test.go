@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultClient_GenerateRepository(t *testing.T) {
+	var request GenerateRepositoryRequest
+	polls := 0
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/acme/billing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+		case "GET /repos/acme/go-template/commits/HEAD":
+			w.Write([]byte(`{"sha": "0123456789abcdef0123456789abcdef01234567"}`))
+		case "POST /repos/acme/go-template/generate":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{
+				"name": "billing", "full_name": "acme/billing", "owner": {"login": "acme"}, "private": true,
+				"html_url": "https://github.com/acme/billing", "clone_url": "https://github.com/acme/billing.git"
+			}`))
+		case "GET /repos/acme/billing/commits":
+			// 生成直後は空のリポジトリとして 409 が返る
+			polls++
+			if polls < 3 {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"message": "Git Repository is empty."}`))
+				return
+			}
+			w.Write([]byte(`[{"sha": "fedcba9876543210fedcba9876543210fedcba98"}]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	client.generatePollInterval = time.Millisecond
+
+	config := &models.ProjectConfig{
+		Name:               "billing",
+		Description:        "Billing service",
+		Template:           &models.Template{Name: "go-template", FullName: "acme/go-template", IsTemplate: true},
+		CreateGitHub:       true,
+		IsPrivate:          true,
+		Owner:              "acme",
+		GenerateOnGitHub:   true,
+		IncludeAllBranches: true,
+	}
+
+	info, err := client.GenerateRepository(context.Background(), config)
+	require.NoError(t, err)
+
+	assert.Equal(t, GenerateRepositoryRequest{
+		Owner:              "acme",
+		Name:               "billing",
+		Description:        "Billing service",
+		IncludeAllBranches: true,
+		Private:            true,
+	}, request)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, "https://github.com/acme/billing.git", info.CloneURL)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", info.TemplateCommit)
+}
+
+func TestDefaultClient_GenerateRepository_DefaultOwner(t *testing.T) {
+	var request GenerateRepositoryRequest
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /user":
+			w.Write([]byte(`{"login": "me"}`))
+		case "GET /repos/me/billing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+		case "GET /repos/acme/go-template/commits/HEAD":
+			w.Write([]byte(`{"sha": "0123456789abcdef0123456789abcdef01234567"}`))
+		case "POST /repos/acme/go-template/generate":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"name": "billing", "full_name": "me/billing"}`))
+		case "GET /repos/me/billing/commits":
+			w.Write([]byte(`[{"sha": "fedcba9876543210fedcba9876543210fedcba98"}]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	config := &models.ProjectConfig{
+		Name:     "billing",
+		Template: &models.Template{Name: "go-template", FullName: "acme/go-template", IsTemplate: true},
+	}
+
+	// オーナー未指定なら認証ユーザーの下に作る
+	_, err := client.GenerateRepository(context.Background(), config)
+	require.NoError(t, err)
+	assert.Equal(t, "me", request.Owner)
+}
+
+func TestDefaultClient_GenerateRepository_NotReady(t *testing.T) {
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/acme/billing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+		case "GET /repos/acme/go-template/commits/HEAD":
+			w.Write([]byte(`{"sha": "0123456789abcdef0123456789abcdef01234567"}`))
+		case "POST /repos/acme/go-template/generate":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"name": "billing", "full_name": "acme/billing"}`))
+		default:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message": "Git Repository is empty."}`))
+		}
+	})
+	client.generatePollInterval = time.Millisecond
+	client.generateTimeout = 20 * time.Millisecond
+
+	config := &models.ProjectConfig{
+		Name:     "billing",
+		Owner:    "acme",
+		Template: &models.Template{Name: "go-template", FullName: "acme/go-template", IsTemplate: true},
+	}
+
+	_, err := client.GenerateRepository(context.Background(), config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Generated repository 'acme/billing' was not ready")
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// optionsFailure prefixes the error of a failed repository options request
+const optionsFailure = "Failed to set repository options"
+
+// RepositoryOptionsUpdateRequest represents the repository fields changed by repository options
+type RepositoryOptionsUpdateRequest struct {
+	Visibility  string `json:"visibility,omitempty"`
+	Homepage    string `json:"homepage,omitempty"`
+	HasIssues   *bool  `json:"has_issues,omitempty"`
+	HasProjects *bool  `json:"has_projects,omitempty"`
+}
+
+// TopicsRequest represents a request replacing the topics of a repository
+type TopicsRequest struct {
+	Names []string `json:"names"`
+}
+
+// CustomPropertyValue is the value of an organization custom property
+type CustomPropertyValue struct {
+	PropertyName string `json:"property_name"`
+	Value        string `json:"value"`
+}
+
+// CustomPropertiesRequest represents a request setting custom property values of a repository
+type CustomPropertiesRequest struct {
+	Properties []CustomPropertyValue `json:"properties"`
+}
+
+// RepositoryOptionRequests returns the REST calls setting options on an existing repository, in order.
+// A repository generated from a template gets its options this way, as the generate endpoint does not take them.
+func RepositoryOptionRequests(fullName string, options models.RepositoryOptions) []APIRequest {
+	var requests []APIRequest
+
+	update := RepositoryOptionsUpdateRequest{
+		Visibility:  options.Visibility,
+		Homepage:    options.Homepage,
+		HasIssues:   options.HasIssues,
+		HasProjects: options.HasProjects,
+	}
+	if update != (RepositoryOptionsUpdateRequest{}) {
+		requests = append(requests, APIRequest{Method: http.MethodPatch, Path: "repos/" + fullName, Body: update})
+	}
+
+	if len(options.Topics) > 0 {
+		requests = append(requests, topicsRequest(fullName, options.Topics))
+	}
+
+	if len(options.CustomProperties) > 0 {
+		names := make([]string, 0, len(options.CustomProperties))
+		for name := range options.CustomProperties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		body := CustomPropertiesRequest{Properties: make([]CustomPropertyValue, len(names))}
+		for i, name := range names {
+			body.Properties[i] = CustomPropertyValue{PropertyName: name, Value: options.CustomProperties[name]}
+		}
+		requests = append(requests, APIRequest{Method: http.MethodPatch, Path: fmt.Sprintf("repos/%s/properties/values", fullName), Body: body})
+	}
+
+	return requests
+}
+
+// topicsRequest returns the REST call replacing the topics of the repository
+func topicsRequest(fullName string, topics []string) APIRequest {
+	return APIRequest{Method: http.MethodPut, Path: fmt.Sprintf("repos/%s/topics", fullName), Body: TopicsRequest{Names: topics}}
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryOptionRequests(t *testing.T) {
+	disabled := false
+	requests := RepositoryOptionRequests("acme/billing", models.RepositoryOptions{
+		Visibility:       "internal",
+		Homepage:         "https://example.com",
+		Topics:           []string{"go", "cli"},
+		HasProjects:      &disabled,
+		CustomProperties: map[string]string{"tier": "1", "team": "platform"},
+	})
+
+	require.Len(t, requests, 3)
+	assert.Equal(t, "PATCH repos/acme/billing", requests[0].String())
+	assert.Equal(t, RepositoryOptionsUpdateRequest{Visibility: "internal", Homepage: "https://example.com", HasProjects: &disabled}, requests[0].Body)
+	assert.Equal(t, "PUT repos/acme/billing/topics", requests[1].String())
+	assert.Equal(t, TopicsRequest{Names: []string{"go", "cli"}}, requests[1].Body)
+	assert.Equal(t, "PATCH repos/acme/billing/properties/values", requests[2].String())
+	assert.Equal(t, CustomPropertiesRequest{Properties: []CustomPropertyValue{
+		{PropertyName: "team", Value: "platform"},
+		{PropertyName: "tier", Value: "1"},
+	}}, requests[2].Body)
+
+	assert.Empty(t, RepositoryOptionRequests("acme/billing", models.RepositoryOptions{}))
+}
+
+func TestDefaultClient_CreateRepository_Options(t *testing.T) {
+	var request map[string]interface{}
+	var topics TopicsRequest
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /user":
+			w.Write([]byte(`{"login": "me", "id": 1}`))
+		case "GET /repos/acme/billing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+		case "POST /orgs/acme/repos":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"name": "billing", "full_name": "acme/billing", "owner": {"login": "acme"}}`))
+		case "PUT /repos/acme/billing/topics":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&topics))
+			w.Write([]byte(`{"names": ["go"]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	disabled := false
+	config := &models.ProjectConfig{
+		Name:  "billing",
+		Owner: "acme",
+		Repository: models.RepositoryOptions{
+			Visibility:       "internal",
+			Homepage:         "https://example.com",
+			Topics:           []string{"go"},
+			HasProjects:      &disabled,
+			CustomProperties: map[string]string{"team": "platform"},
+		},
+	}
+	_, err := client.CreateRepository(context.Background(), config)
+	require.NoError(t, err)
+
+	// トピック以外は作成時に指定する
+	assert.Equal(t, "internal", request["visibility"])
+	assert.Equal(t, "https://example.com", request["homepage"])
+	assert.Equal(t, false, request["has_projects"])
+	assert.NotContains(t, request, "has_issues")
+	assert.Equal(t, map[string]interface{}{"team": "platform"}, request["custom_properties"])
+	assert.Equal(t, []string{"go"}, topics.Names)
+}
+
+func TestDefaultClient_GenerateRepository_Options(t *testing.T) {
+	var generate map[string]interface{}
+	var update RepositoryOptionsUpdateRequest
+	var topics TopicsRequest
+	var requests []string
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/acme/billing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+		case "GET /repos/acme/go-template/commits/HEAD":
+			w.Write([]byte(`{"sha": "0123456789abcdef0123456789abcdef01234567"}`))
+		case "POST /repos/acme/go-template/generate":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&generate))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"name": "billing", "full_name": "acme/billing", "owner": {"login": "acme"}}`))
+		case "GET /repos/acme/billing/commits":
+			w.Write([]byte(`[{"sha": "fedcba9876543210fedcba9876543210fedcba98"}]`))
+		case "PATCH /repos/acme/billing":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			w.Write([]byte(`{}`))
+		case "PUT /repos/acme/billing/topics":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&topics))
+			w.Write([]byte(`{"names": ["go"]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	client.generatePollInterval = time.Millisecond
+
+	config := &models.ProjectConfig{
+		Name:             "billing",
+		Template:         &models.Template{Name: "go-template", FullName: "acme/go-template", IsTemplate: true},
+		CreateGitHub:     true,
+		IsPrivate:        true,
+		Owner:            "acme",
+		GenerateOnGitHub: true,
+		Repository:       models.RepositoryOptions{Visibility: "internal", Homepage: "https://example.com", Topics: []string{"go"}},
+	}
+	_, err := client.GenerateRepository(context.Background(), config)
+	require.NoError(t, err)
+
+	// 生成のエンドポイントはオプションを受け取らないので、生成後に設定する
+	assert.NotContains(t, generate, "homepage")
+	assert.Equal(t, RepositoryOptionsUpdateRequest{Visibility: "internal", Homepage: "https://example.com"}, update)
+	assert.Equal(t, []string{"go"}, topics.Names)
+	assert.Equal(t, []string{"PATCH /repos/acme/billing", "PUT /repos/acme/billing/topics"}, requests[len(requests)-2:])
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// orgMembership is an entry of the user's organization memberships
+type orgMembership struct {
+	Role         string `json:"role"`
+	Organization struct {
+		Login string `json:"login"`
+	} `json:"organization"`
+}
+
+// TeamRepositoryRequest represents a request granting a team access to a repository
+type TeamRepositoryRequest struct {
+	Permission string `json:"permission"`
+}
+
+// ListRepositoryOwners lists the authenticated user followed by the organizations they can create
+// repositories in: organizations they administer, and those letting members create repositories
+func (c *DefaultClient) ListRepositoryOwners(ctx context.Context) ([]string, error) {
+	user, err := c.getCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owners := []string{user.Login}
+
+	for page := 1; ; page++ {
+		var memberships []orgMembership
+		path := fmt.Sprintf("user/memberships/orgs?state=active&per_page=%d&page=%d", restPageSize, page)
+		if err := c.rest(ctx, http.MethodGet, path, nil, &memberships); err != nil {
+			return nil, models.NewGitHubError("Failed to list organization memberships", err)
+		}
+
+		for _, membership := range memberships {
+			org := membership.Organization.Login
+			if membership.Role != "admin" {
+				canCreate, err := c.membersCanCreateRepositories(ctx, org)
+				if err != nil {
+					return nil, err
+				}
+				if !canCreate {
+					continue
+				}
+			}
+			owners = append(owners, org)
+		}
+
+		if len(memberships) < restPageSize {
+			break
+		}
+	}
+
+	return owners, nil
+}
+
+// membersCanCreateRepositories reports whether members of an organization may create repositories
+func (c *DefaultClient) membersCanCreateRepositories(ctx context.Context, org string) (bool, error) {
+	var organization struct {
+		// MembersCanCreateRepositories is only returned to members, so a missing value is treated as allowed
+		MembersCanCreateRepositories *bool `json:"members_can_create_repositories"`
+	}
+	if err := c.rest(ctx, http.MethodGet, "orgs/"+org, nil, &organization); err != nil {
+		return false, models.NewGitHubError(fmt.Sprintf("Failed to get organization '%s'", org), err)
+	}
+	return organization.MembersCanCreateRepositories == nil || *organization.MembersCanCreateRepositories, nil
+}
+
+// GrantTeamAccess gives a team of the organization owning the repository access to it
+func (c *DefaultClient) GrantTeamAccess(ctx context.Context, fullName string, grant models.TeamGrant) error {
+	org, _, _ := strings.Cut(fullName, "/")
+
+	path := fmt.Sprintf("orgs/%s/teams/%s/repos/%s", org, grant.Team, fullName)
+	if err := c.rest(ctx, http.MethodPut, path, TeamRepositoryRequest{Permission: grant.Permission}, nil); err != nil {
+		return models.NewGitHubError(fmt.Sprintf("Failed to grant team '%s' %s access to '%s'", grant.Team, grant.Permission, fullName), err)
+	}
+	return nil
+}
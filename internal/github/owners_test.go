@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultClient_ListRepositoryOwners(t *testing.T) {
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /user":
+			w.Write([]byte(`{"login": "me"}`))
+		case "GET /user/memberships/orgs":
+			assert.Equal(t, "active", r.URL.Query().Get("state"))
+			w.Write([]byte(`[
+				{"role": "admin", "organization": {"login": "acme"}},
+				{"role": "member", "organization": {"login": "open"}},
+				{"role": "member", "organization": {"login": "locked"}},
+				{"role": "member", "organization": {"login": "quiet"}}
+			]`))
+		case "GET /orgs/open":
+			w.Write([]byte(`{"login": "open", "members_can_create_repositories": true}`))
+		case "GET /orgs/locked":
+			w.Write([]byte(`{"login": "locked", "members_can_create_repositories": false}`))
+		case "GET /orgs/quiet":
+			w.Write([]byte(`{"login": "quiet"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	// 管理者の組織と、メンバーが作成できる組織だけが並ぶ
+	owners, err := client.ListRepositoryOwners(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"me", "acme", "open", "quiet"}, owners)
+}
+
+func TestDefaultClient_GrantTeamAccess(t *testing.T) {
+	var request TeamRepositoryRequest
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /orgs/acme/teams/backend/repos/acme/billing":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	})
+
+	err := client.GrantTeamAccess(context.Background(), "acme/billing", models.TeamGrant{Team: "backend", Permission: "maintain"})
+	require.NoError(t, err)
+	assert.Equal(t, "maintain", request.Permission)
+
+	// 存在しないチームはエラーになる
+	err = client.GrantTeamAccess(context.Background(), "acme/billing", models.TeamGrant{Team: "missing", Permission: "push"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to grant team 'missing' push access to 'acme/billing'")
+}
@@ -4,62 +4,112 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
 	"github.com/cli/go-gh/v2/pkg/api"
 )
 
-// RepositoryService provides GitHub repository operations
-type RepositoryService struct {
-	client *api.RESTClient
+// restClient returns a REST client for the authenticated GitHub host
+func (c *DefaultClient) restClient() (*api.RESTClient, error) {
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, models.NewGitHubError("Failed to initialize GitHub CLI", err)
+	}
+	return client, nil
 }
 
-// NewRepositoryService creates a new repository service
-func NewRepositoryService() (*RepositoryService, error) {
-	client, err := api.DefaultRESTClient()
+// rest sends a REST request to path, relative to the API base URL
+func (c *DefaultClient) rest(ctx context.Context, method, path string, body interface{}, response interface{}) error {
+	client, err := c.restClient()
 	if err != nil {
-		return nil, models.NewGitHubError(
-			"Failed to initialize GitHub CLI",
-			err,
-		)
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return models.NewGitHubError("Failed to create request data", err)
+		}
+		reader = bytes.NewReader(data)
 	}
 
-	return &RepositoryService{client: client}, nil
+	return client.DoWithContext(ctx, method, c.apiURL+path, reader, response)
 }
 
-// CreateRepository creates a GitHub repository
-func (rs *RepositoryService) CreateRepository(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error) {
-	if !config.CreateGitHub {
-		return nil, nil // GitHub repository creation not required
+// isNotFound reports whether a REST error is a 404 response
+func isNotFound(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
+
+// CheckAuthentication checks that gh has a token the API accepts
+func (c *DefaultClient) CheckAuthentication(ctx context.Context) error {
+	if _, err := c.getCurrentUser(ctx); err != nil {
+		return models.NewValidationError("Not logged in to GitHub CLI. Please run 'gh auth login'.")
 	}
+	return nil
+}
 
+// CreateRepository creates an empty GitHub repository for the project under config.Owner,
+// or the authenticated user when it is empty or names the user
+func (c *DefaultClient) CreateRepository(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error) {
 	// Get current user information
-	user, err := rs.getCurrentUser(ctx)
+	user, err := c.getCurrentUser(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	owner, path := user.Login, "user/repos"
+	if config.Owner != "" && !strings.EqualFold(config.Owner, user.Login) {
+		owner, path = config.Owner, fmt.Sprintf("orgs/%s/repos", config.Owner)
+	}
+
 	// Check for repository duplication
-	if err := rs.checkRepositoryExists(ctx, user.Login, config.Name); err != nil {
+	if err := c.checkRepositoryExists(ctx, owner, config.Name); err != nil {
 		return nil, err
 	}
 
-	// Create repository
-	repoInfo, err := rs.createRepositoryViaAPI(ctx, config)
-	if err != nil {
-		return nil, err
+	// The project is committed locally and pushed, so the repository starts empty
+	options := config.Repository
+	request := CreateRepositoryRequest{
+		Name:             config.Name,
+		Description:      config.Description,
+		Private:          config.IsPrivate,
+		Visibility:       options.Visibility,
+		Homepage:         options.Homepage,
+		HasIssues:        options.HasIssues,
+		HasProjects:      options.HasProjects,
+		CustomProperties: options.CustomProperties,
+	}
+
+	var repoInfo RepositoryInfo
+	if err := c.rest(ctx, http.MethodPost, path, request, &repoInfo); err != nil {
+		return nil, models.NewGitHubError(
+			fmt.Sprintf("Failed to create repository '%s/%s'", owner, config.Name),
+			err,
+		)
+	}
+
+	// The creation endpoints do not take topics
+	if len(options.Topics) > 0 {
+		if err := c.sendRequests(ctx, optionsFailure, []APIRequest{topicsRequest(repoInfo.FullName, options.Topics)}); err != nil {
+			return nil, err
+		}
 	}
 
-	return repoInfo, nil
+	return &repoInfo, nil
 }
 
 // getCurrentUser gets current GitHub user information
-func (rs *RepositoryService) getCurrentUser(ctx context.Context) (*GitHubUser, error) {
+func (c *DefaultClient) getCurrentUser(ctx context.Context) (*GitHubUser, error) {
 	var user GitHubUser
-	err := rs.client.Get("user", &user)
-	if err != nil {
+	if err := c.rest(ctx, http.MethodGet, "user", nil, &user); err != nil {
 		return nil, models.NewGitHubError(
 			"Failed to get user information",
 			err,
@@ -69,9 +119,9 @@ func (rs *RepositoryService) getCurrentUser(ctx context.Context) (*GitHubUser, e
 }
 
 // checkRepositoryExists checks for repository duplication
-func (rs *RepositoryService) checkRepositoryExists(ctx context.Context, owner, name string) error {
+func (c *DefaultClient) checkRepositoryExists(ctx context.Context, owner, name string) error {
 	var repo RepositoryInfo
-	err := rs.client.Get(fmt.Sprintf("repos/%s/%s", owner, name), &repo)
+	err := c.rest(ctx, http.MethodGet, fmt.Sprintf("repos/%s/%s", owner, name), nil, &repo)
 
 	if err == nil {
 		// Repository exists
@@ -82,7 +132,7 @@ func (rs *RepositoryService) checkRepositoryExists(ctx context.Context, owner, n
 	}
 
 	// 404 error is normal (repository doesn't exist)
-	if strings.Contains(err.Error(), "404") {
+	if isNotFound(err) {
 		return nil
 	}
 
@@ -93,43 +143,6 @@ func (rs *RepositoryService) checkRepositoryExists(ctx context.Context, owner, n
 	)
 }
 
-// createRepositoryViaAPI creates repository via API
-func (rs *RepositoryService) createRepositoryViaAPI(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error) {
-	// Create request body
-	createReq := CreateRepositoryRequest{
-		Name:        config.Name,
-		Description: config.Description,
-		Private:     config.IsPrivate,
-		AutoInit:    false, // Already initialized by template or locally
-	}
-
-	// For template repository
-	if config.HasTemplate() {
-		createReq.TemplateOwner = config.Template.Owner
-		createReq.TemplateRepo = config.Template.Name
-	}
-
-	// Convert request body to JSON
-	jsonData, err := json.Marshal(createReq)
-	if err != nil {
-		return nil, models.NewGitHubError(
-			"Failed to create request data",
-			err,
-		)
-	}
-
-	var repoInfo RepositoryInfo
-	err = rs.client.Post("user/repos", bytes.NewReader(jsonData), &repoInfo)
-	if err != nil {
-		return nil, models.NewGitHubError(
-			fmt.Sprintf("Failed to create repository: %v", err),
-			err,
-		)
-	}
-
-	return &repoInfo, nil
-}
-
 // Data structures
 
 // GitHubUser represents GitHub user information
@@ -151,14 +164,21 @@ type RepositoryInfo struct {
 	SSHURL    string     `json:"ssh_url"`
 	GitURL    string     `json:"git_url"`
 	CreatedAt string     `json:"created_at"`
+	// TemplateCommit is the template commit a generated repository was created from
+	TemplateCommit string `json:"-"`
 }
 
 // CreateRepositoryRequest represents repository creation request
 type CreateRepositoryRequest struct {
-	Name          string `json:"name"`
-	Description   string `json:"description,omitempty"`
-	Private       bool   `json:"private"`
-	AutoInit      bool   `json:"auto_init"`
-	TemplateOwner string `json:"template_owner,omitempty"`
-	TemplateRepo  string `json:"template_repo,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Private     bool   `json:"private"`
+	// Visibility takes precedence over Private; organization repositories only
+	Visibility  string `json:"visibility,omitempty"`
+	Homepage    string `json:"homepage,omitempty"`
+	HasIssues   *bool  `json:"has_issues,omitempty"`
+	HasProjects *bool  `json:"has_projects,omitempty"`
+	AutoInit    bool   `json:"auto_init"`
+	// CustomProperties sets organization custom properties; organization repositories only
+	CustomProperties map[string]string `json:"custom_properties,omitempty"`
 }
@@ -0,0 +1,107 @@
+package github
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// RulesetRequest represents a request creating a repository ruleset
+type RulesetRequest struct {
+	Name        string            `json:"name"`
+	Target      string            `json:"target"`
+	Enforcement string            `json:"enforcement"`
+	Conditions  RulesetConditions `json:"conditions"`
+	Rules       []RulesetRule     `json:"rules"`
+}
+
+// RulesetConditions selects the branches a ruleset applies to
+type RulesetConditions struct {
+	RefName RulesetRefName `json:"ref_name"`
+}
+
+// RulesetRefName lists the included and excluded refs of a ruleset
+type RulesetRefName struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// RulesetRule is a rule of a ruleset; Parameters depends on Type
+type RulesetRule struct {
+	Type       string      `json:"type"`
+	Parameters interface{} `json:"parameters,omitempty"`
+}
+
+// PullRequestRuleParameters are the parameters of the pull_request rule
+type PullRequestRuleParameters struct {
+	RequiredApprovingReviewCount   int  `json:"required_approving_review_count"`
+	DismissStaleReviewsOnPush      bool `json:"dismiss_stale_reviews_on_push"`
+	RequireCodeOwnerReview         bool `json:"require_code_owner_review"`
+	RequireLastPushApproval        bool `json:"require_last_push_approval"`
+	RequiredReviewThreadResolution bool `json:"required_review_thread_resolution"`
+}
+
+// StatusCheckRuleParameters are the parameters of the required_status_checks rule
+type StatusCheckRuleParameters struct {
+	StrictRequiredStatusChecksPolicy bool                  `json:"strict_required_status_checks_policy"`
+	RequiredStatusChecks             []RequiredStatusCheck `json:"required_status_checks"`
+}
+
+// RequiredStatusCheck is a status check required by a ruleset
+type RequiredStatusCheck struct {
+	Context string `json:"context"`
+}
+
+// rulesetRequest returns the REST call creating the ruleset in the repository
+func rulesetRequest(fullName string, ruleset models.Ruleset) APIRequest {
+	body := RulesetRequest{
+		Name:        ruleset.Name,
+		Target:      "branch",
+		Enforcement: ruleset.GetEnforcement(),
+		Conditions: RulesetConditions{RefName: RulesetRefName{
+			Include: rulesetRefs(ruleset.Branches),
+			Exclude: rulesetRefs(ruleset.Exclude),
+		}},
+	}
+
+	if ruleset.RequiresPullRequest() {
+		body.Rules = append(body.Rules, RulesetRule{Type: "pull_request", Parameters: PullRequestRuleParameters{
+			RequiredApprovingReviewCount: ruleset.RequiredReviews,
+			DismissStaleReviewsOnPush:    ruleset.DismissStaleReviews,
+			RequireCodeOwnerReview:       ruleset.RequireCodeOwnerReviews,
+		}})
+	}
+	if len(ruleset.RequiredStatusChecks) > 0 {
+		checks := make([]RequiredStatusCheck, len(ruleset.RequiredStatusChecks))
+		for i, check := range ruleset.RequiredStatusChecks {
+			checks[i] = RequiredStatusCheck{Context: check}
+		}
+		body.Rules = append(body.Rules, RulesetRule{Type: "required_status_checks", Parameters: StatusCheckRuleParameters{
+			StrictRequiredStatusChecksPolicy: ruleset.StrictStatusChecks,
+			RequiredStatusChecks:             checks,
+		}})
+	}
+	if ruleset.LinearHistory {
+		body.Rules = append(body.Rules, RulesetRule{Type: "required_linear_history"})
+	}
+	if ruleset.SignedCommits {
+		body.Rules = append(body.Rules, RulesetRule{Type: "required_signatures"})
+	}
+
+	return APIRequest{Method: http.MethodPost, Path: "repos/" + fullName + "/rulesets", Body: body}
+}
+
+// rulesetRefs converts branch names and patterns to the refs rulesets match.
+// The special ~DEFAULT_BRANCH and ~ALL targets and full refs are kept as they are.
+func rulesetRefs(branches []string) []string {
+	refs := make([]string, len(branches))
+	for i, branch := range branches {
+		if strings.HasPrefix(branch, "~") || strings.HasPrefix(branch, "refs/") {
+			refs[i] = branch
+		} else {
+			refs[i] = "refs/heads/" + branch
+		}
+	}
+	return refs
+}
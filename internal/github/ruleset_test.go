@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testRulesetsYAML = `
+rulesets:
+  - name: protect-main
+    branches: [~DEFAULT_BRANCH, "release/*"]
+    exclude: [release/legacy]
+    required_reviews: 2
+    dismiss_stale_reviews: true
+    required_status_checks: [test, lint]
+    strict_status_checks: true
+    linear_history: true
+    signed_commits: true
+  - name: signed-tags-trial
+    enforcement: evaluate
+    branches: [refs/heads/main]
+    signed_commits: true
+`
+
+func TestDefaultClient_ApplyRepositorySettings_Rulesets(t *testing.T) {
+	client, recorder := newRecordingTestClient(t, func(r *http.Request) int {
+		return http.StatusCreated
+	})
+
+	var settings models.RepositorySettings
+	require.NoError(t, yaml.Unmarshal([]byte(testRulesetsYAML), &settings))
+	require.NoError(t, settings.Validate())
+
+	require.NoError(t, client.ApplyRepositorySettings(context.Background(), "acme/billing", &settings))
+
+	require.Len(t, recorder.requests, 2)
+	assert.Equal(t, recordedRequest{
+		Method: http.MethodPost,
+		Path:   "/repos/acme/billing/rulesets",
+		Body: map[string]interface{}{
+			"name":        "protect-main",
+			"target":      "branch",
+			"enforcement": "active",
+			"conditions": map[string]interface{}{
+				"ref_name": map[string]interface{}{
+					"include": []interface{}{"~DEFAULT_BRANCH", "refs/heads/release/*"},
+					"exclude": []interface{}{"refs/heads/release/legacy"},
+				},
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"type": "pull_request",
+					"parameters": map[string]interface{}{
+						"required_approving_review_count":   float64(2),
+						"dismiss_stale_reviews_on_push":     true,
+						"require_code_owner_review":         false,
+						"require_last_push_approval":        false,
+						"required_review_thread_resolution": false,
+					},
+				},
+				map[string]interface{}{
+					"type": "required_status_checks",
+					"parameters": map[string]interface{}{
+						"strict_required_status_checks_policy": true,
+						"required_status_checks": []interface{}{
+							map[string]interface{}{"context": "test"},
+							map[string]interface{}{"context": "lint"},
+						},
+					},
+				},
+				map[string]interface{}{"type": "required_linear_history"},
+				map[string]interface{}{"type": "required_signatures"},
+			},
+		},
+	}, recorder.requests[0])
+
+	// 評価モードのルールセットはそのまま送り、完全な ref は変換しない
+	second := recorder.requests[1].Body
+	assert.Equal(t, "evaluate", second["enforcement"])
+	assert.Equal(t, map[string]interface{}{
+		"ref_name": map[string]interface{}{"include": []interface{}{"refs/heads/main"}, "exclude": []interface{}{}},
+	}, second["conditions"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "required_signatures"}}, second["rules"])
+}
+
+func TestDefaultClient_ApplyRepositorySettings_RulesetError(t *testing.T) {
+	client, recorder := newRecordingTestClient(t, func(r *http.Request) int {
+		// プランによってはルールセットが使えない
+		return http.StatusForbidden
+	})
+
+	settings := &models.RepositorySettings{Rulesets: []models.Ruleset{
+		{Name: "protect-main", Branches: []string{"main"}, LinearHistory: true},
+	}}
+
+	err := client.ApplyRepositorySettings(context.Background(), "acme/billing", settings)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "POST repos/acme/billing/rulesets")
+	assert.Len(t, recorder.requests, 1)
+}
@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// searchResultLimit caps how many search results are listed
+const searchResultLimit = 50
+
+// searchResponse is the response of the repository search API
+type searchResponse struct {
+	TotalCount int              `json:"total_count"`
+	Items      []restRepository `json:"items"`
+}
+
+// SearchTemplates searches public template repositories on GitHub matching the keywords, most starred first
+func (c *DefaultClient) SearchTemplates(ctx context.Context, keywords string) ([]models.Template, error) {
+	keywords = strings.TrimSpace(keywords)
+	if keywords == "" {
+		return nil, models.NewValidationError("search keywords are required")
+	}
+
+	query := url.Values{}
+	query.Set("q", keywords+" is:template")
+	query.Set("sort", "stars")
+	query.Set("order", "desc")
+	query.Set("per_page", fmt.Sprint(searchResultLimit))
+
+	var response searchResponse
+	if err := c.rest(ctx, http.MethodGet, "search/repositories?"+query.Encode(), nil, &response); err != nil {
+		return nil, models.NewGitHubError(fmt.Sprintf("Failed to search templates for '%s'", keywords), err)
+	}
+
+	templates := make([]models.Template, 0, len(response.Items))
+	for _, repo := range response.Items {
+		templates = append(templates, repo.toTemplate())
+	}
+
+	return templates, nil
+}
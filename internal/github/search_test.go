@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRESTTestClient は GitHub REST API の代わりにローカルサーバーを使うクライアントを作成する
+func newRESTTestClient(t *testing.T, handler http.HandlerFunc) *DefaultClient {
+	t.Setenv("GH_TOKEN", "test-token")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &DefaultClient{apiURL: server.URL + "/"}
+}
+
+func TestDefaultClient_SearchTemplates(t *testing.T) {
+	var query map[string][]string
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/search/repositories", r.URL.Path)
+		query = r.URL.Query()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"total_count": 2,
+			"items": [
+				{
+					"id": 1, "name": "fastapi-template", "full_name": "tiangolo/fastapi-template",
+					"owner": {"login": "tiangolo"}, "description": "FastAPI project template",
+					"stargazers_count": 900, "forks_count": 120, "language": "Python",
+					"topics": ["fastapi"], "is_template": true, "updated_at": "2024-04-01T00:00:00Z",
+					"clone_url": "https://github.com/tiangolo/fastapi-template.git"
+				},
+				{"id": 2, "name": "fastapi-demo", "full_name": "someone/fastapi-demo", "owner": {"login": "someone"}}
+			]
+		}`))
+	})
+
+	templates, err := client.SearchTemplates(context.Background(), " fastapi ")
+	require.NoError(t, err)
+
+	// キーワードに is:template を付けてスター順に検索する
+	assert.Equal(t, []string{"fastapi is:template"}, query["q"])
+	assert.Equal(t, []string{"stars"}, query["sort"])
+
+	// テンプレートでないものも含めてそのまま返す（検証は呼び出し側で行う）
+	require.Len(t, templates, 2)
+	assert.Equal(t, "tiangolo/fastapi-template", templates[0].FullName)
+	assert.Equal(t, "tiangolo", templates[0].Owner)
+	assert.Equal(t, 900, templates[0].Stars)
+	assert.Equal(t, 2024, templates[0].UpdatedAt.Year())
+	assert.True(t, templates[0].IsTemplate)
+	assert.False(t, templates[1].IsTemplate)
+}
+
+func TestDefaultClient_SearchTemplates_Errors(t *testing.T) {
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "Validation Failed"}`))
+	})
+
+	_, err := client.SearchTemplates(context.Background(), "fastapi")
+	assert.ErrorContains(t, err, "Failed to search templates for 'fastapi'")
+
+	// キーワードが空なら検索しない
+	_, err = client.SearchTemplates(context.Background(), "  ")
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// settingsFailure prefixes the error of a failed repository settings request
+const settingsFailure = "Failed to apply repository settings"
+
+// APIRequest is a REST call gh-wizard makes against a repository
+type APIRequest struct {
+	Method string
+	Path   string
+	Body   interface{}
+}
+
+// String returns the request as "METHOD path"
+func (r APIRequest) String() string {
+	return r.Method + " " + r.Path
+}
+
+// RepositoryUpdateRequest represents the repository fields changed by repository settings
+type RepositoryUpdateRequest struct {
+	AllowMergeCommit    *bool `json:"allow_merge_commit,omitempty"`
+	AllowSquashMerge    *bool `json:"allow_squash_merge,omitempty"`
+	AllowRebaseMerge    *bool `json:"allow_rebase_merge,omitempty"`
+	DeleteBranchOnMerge *bool `json:"delete_branch_on_merge,omitempty"`
+	HasWiki             *bool `json:"has_wiki,omitempty"`
+}
+
+// BranchProtectionRequest represents a request to the branch protection endpoint.
+// The endpoint requires every field, with null disabling a rule.
+type BranchProtectionRequest struct {
+	RequiredStatusChecks       *RequiredStatusChecks       `json:"required_status_checks"`
+	EnforceAdmins              bool                        `json:"enforce_admins"`
+	RequiredPullRequestReviews *RequiredPullRequestReviews `json:"required_pull_request_reviews"`
+	Restrictions               *struct{}                   `json:"restrictions"`
+}
+
+// RequiredStatusChecks are the status checks a protected branch requires
+type RequiredStatusChecks struct {
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+}
+
+// RequiredPullRequestReviews are the reviews a protected branch requires
+type RequiredPullRequestReviews struct {
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+}
+
+// RepositorySettingsRequests returns the REST calls applying settings to a newly created repository, in order.
+// Labels, milestones and issues are synced against what the repository has, so on an existing
+// repository ApplyRepositorySettings may send fewer of them.
+func RepositorySettingsRequests(fullName string, settings *models.RepositorySettings) []APIRequest {
+	if settings.IsEmpty() {
+		return nil
+	}
+
+	contents := newRepositoryContents()
+	requests := settingsRequests(fullName, settings)
+	requests = append(requests, labelRequests(fullName, settings, contents.labels)...)
+	requests = append(requests, milestoneRequests(fullName, settings, contents.milestones)...)
+	return append(requests, issueRequests(fullName, settings, contents.milestones, contents.issues)...)
+}
+
+// settingsRequests returns the REST calls applying the repository, branch protection and ruleset settings
+func settingsRequests(fullName string, settings *models.RepositorySettings) []APIRequest {
+	var requests []APIRequest
+
+	update := RepositoryUpdateRequest{
+		DeleteBranchOnMerge: settings.DeleteBranchOnMerge,
+		HasWiki:             settings.HasWiki,
+	}
+	if len(settings.MergeStrategies) > 0 {
+		allowed := func(strategy string) *bool {
+			for _, s := range settings.MergeStrategies {
+				if s == strategy {
+					return boolPtr(true)
+				}
+			}
+			return boolPtr(false)
+		}
+		update.AllowMergeCommit = allowed("merge")
+		update.AllowSquashMerge = allowed("squash")
+		update.AllowRebaseMerge = allowed("rebase")
+	}
+	if update != (RepositoryUpdateRequest{}) {
+		requests = append(requests, APIRequest{Method: http.MethodPatch, Path: "repos/" + fullName, Body: update})
+	}
+
+	for _, protection := range settings.BranchProtection {
+		body := BranchProtectionRequest{EnforceAdmins: protection.EnforceAdmins}
+		if len(protection.RequiredStatusChecks) > 0 {
+			body.RequiredStatusChecks = &RequiredStatusChecks{
+				Strict:   protection.StrictStatusChecks,
+				Contexts: protection.RequiredStatusChecks,
+			}
+		}
+		if protection.RequiredReviews > 0 || protection.DismissStaleReviews || protection.RequireCodeOwnerReviews {
+			body.RequiredPullRequestReviews = &RequiredPullRequestReviews{
+				RequiredApprovingReviewCount: protection.RequiredReviews,
+				DismissStaleReviews:          protection.DismissStaleReviews,
+				RequireCodeOwnerReviews:      protection.RequireCodeOwnerReviews,
+			}
+		}
+
+		path := fmt.Sprintf("repos/%s/branches/%s/protection", fullName, url.PathEscape(protection.Branch))
+		requests = append(requests, APIRequest{Method: http.MethodPut, Path: path, Body: body})
+	}
+
+	for _, ruleset := range settings.Rulesets {
+		requests = append(requests, rulesetRequest(fullName, ruleset))
+	}
+
+	return requests
+}
+
+// ApplyRepositorySettings applies settings to the repository.
+// Branch protection needs the branches to exist, so it is applied after the project is pushed.
+func (c *DefaultClient) ApplyRepositorySettings(ctx context.Context, fullName string, settings *models.RepositorySettings) error {
+	if settings.IsEmpty() {
+		return nil
+	}
+
+	if err := c.sendRequests(ctx, settingsFailure, settingsRequests(fullName, settings)); err != nil {
+		return err
+	}
+	if settings.HasBootstrap() {
+		return c.syncRepositoryContents(ctx, fullName, settings)
+	}
+	return nil
+}
+
+// sendRequests sends the requests in order, stopping at the first failure, which is reported
+// as message followed by the failed request
+func (c *DefaultClient) sendRequests(ctx context.Context, message string, requests []APIRequest) error {
+	for _, request := range requests {
+		if err := c.rest(ctx, request.Method, request.Path, request.Body, nil); err != nil {
+			return models.NewGitHubError(fmt.Sprintf("%s (%s)", message, request), err)
+		}
+	}
+	return nil
+}
+
+// boolPtr returns a pointer to b
+func boolPtr(b bool) *bool {
+	return &b
+}
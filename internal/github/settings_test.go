@@ -0,0 +1,143 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedRequest is a request received by the recording test server
+type recordedRequest struct {
+	Method string
+	Path   string
+	Body   map[string]interface{}
+}
+
+// requestRecorder answers every request with a fixed status and records it
+type requestRecorder struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+	status   func(r *http.Request) int
+}
+
+// newRecordingTestClient returns a client whose requests are recorded by the returned recorder
+func newRecordingTestClient(t *testing.T, status func(r *http.Request) int) (*DefaultClient, *requestRecorder) {
+	recorder := &requestRecorder{status: status}
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		request := recordedRequest{Method: r.Method, Path: r.URL.Path}
+		if len(data) > 0 {
+			require.NoError(t, json.Unmarshal(data, &request.Body))
+		}
+
+		recorder.mu.Lock()
+		recorder.requests = append(recorder.requests, request)
+		recorder.mu.Unlock()
+
+		code := http.StatusOK
+		if recorder.status != nil {
+			code = recorder.status(r)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		if code >= http.StatusBadRequest {
+			w.Write([]byte(`{"message": "Validation Failed"}`))
+		} else {
+			w.Write([]byte(`{}`))
+		}
+	})
+	return client, recorder
+}
+
+func TestDefaultClient_ApplyRepositorySettings(t *testing.T) {
+	client, recorder := newRecordingTestClient(t, nil)
+
+	enabled, disabled := true, false
+	settings := &models.RepositorySettings{
+		MergeStrategies:     []string{"squash"},
+		DeleteBranchOnMerge: &enabled,
+		HasWiki:             &disabled,
+		BranchProtection: []models.BranchProtection{
+			{Branch: "main", RequiredReviews: 1, DismissStaleReviews: true, RequiredStatusChecks: []string{"test", "lint"}, StrictStatusChecks: true},
+			{Branch: "release/v1", EnforceAdmins: true},
+		},
+	}
+
+	require.NoError(t, client.ApplyRepositorySettings(context.Background(), "acme/billing", settings))
+
+	require.Len(t, recorder.requests, 3)
+	assert.Equal(t, recordedRequest{
+		Method: http.MethodPatch,
+		Path:   "/repos/acme/billing",
+		Body: map[string]interface{}{
+			"allow_merge_commit":     false,
+			"allow_squash_merge":     true,
+			"allow_rebase_merge":     false,
+			"delete_branch_on_merge": true,
+			"has_wiki":               false,
+		},
+	}, recorder.requests[0])
+	assert.Equal(t, recordedRequest{
+		Method: http.MethodPut,
+		Path:   "/repos/acme/billing/branches/main/protection",
+		Body: map[string]interface{}{
+			"required_status_checks": map[string]interface{}{"strict": true, "contexts": []interface{}{"test", "lint"}},
+			"enforce_admins":         false,
+			"required_pull_request_reviews": map[string]interface{}{
+				"required_approving_review_count": float64(1),
+				"dismiss_stale_reviews":           true,
+				"require_code_owner_reviews":      false,
+			},
+			"restrictions": nil,
+		},
+	}, recorder.requests[1])
+
+	// ルールのない項目は null で送り、ブランチ名はエスケープする
+	assert.Equal(t, "/repos/acme/billing/branches/release/v1/protection", recorder.requests[2].Path)
+	assert.Equal(t, map[string]interface{}{
+		"required_status_checks":        nil,
+		"enforce_admins":                true,
+		"required_pull_request_reviews": nil,
+		"restrictions":                  nil,
+	}, recorder.requests[2].Body)
+}
+
+func TestDefaultClient_ApplyRepositorySettings_Error(t *testing.T) {
+	client, recorder := newRecordingTestClient(t, func(r *http.Request) int {
+		if r.Method == http.MethodPut {
+			return http.StatusForbidden
+		}
+		return http.StatusOK
+	})
+
+	settings := &models.RepositorySettings{
+		MergeStrategies:  []string{"squash"},
+		BranchProtection: []models.BranchProtection{{Branch: "main", RequiredReviews: 1}, {Branch: "develop"}},
+	}
+
+	// 失敗したリクエストで止まり、どの呼び出しかを示す
+	err := client.ApplyRepositorySettings(context.Background(), "acme/billing", settings)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PUT repos/acme/billing/branches/main/protection")
+	assert.Len(t, recorder.requests, 2)
+}
+
+func TestRepositorySettingsRequests(t *testing.T) {
+	// 設定がなければリクエストもない
+	assert.Empty(t, RepositorySettingsRequests("acme/billing", nil))
+
+	requests := RepositorySettingsRequests("acme/billing", &models.RepositorySettings{
+		BranchProtection: []models.BranchProtection{{Branch: "main"}},
+	})
+	require.Len(t, requests, 1)
+	assert.Equal(t, "PUT repos/acme/billing/branches/main/protection", requests[0].String())
+}
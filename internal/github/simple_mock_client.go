@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,9 +13,22 @@ import (
 // SimpleMockClient は設定可能なモッククライアント
 type SimpleMockClient struct {
 	Templates     []models.Template
+	Files         map[string][]byte
 	AuthError     error
 	CreateError   error
 	TemplateError error
+	// Repository は CreateRepository が返すリポジトリ（nil なら testuser/<プロジェクト名>）
+	Repository *RepositoryInfo
+	// Created は CreateRepository に渡されたプロジェクト
+	Created []*models.ProjectConfig
+	// Generated は GenerateRepository に渡されたプロジェクト
+	Generated []*models.ProjectConfig
+	// Owners は ListRepositoryOwners が返すオーナー（nil なら testuser のみ）
+	Owners []string
+	// Granted は GrantTeamAccess に渡されたリポジトリ名とチーム（"owner/repo team:permission"）
+	Granted []string
+	// Applied は ApplyRepositorySettings が送るはずのリクエスト
+	Applied []APIRequest
 }
 
 // NewSimpleMockClient は新しいシンプルモッククライアントを作成する
@@ -55,6 +69,21 @@ func (c *SimpleMockClient) SearchPopularTemplates(ctx context.Context) ([]models
 	return c.Templates, nil
 }
 
+// SearchTemplates はモック公開テンプレート検索（名前か説明にキーワードを含むものを返す）
+func (c *SimpleMockClient) SearchTemplates(ctx context.Context, keywords string) ([]models.Template, error) {
+	if c.TemplateError != nil {
+		return nil, c.TemplateError
+	}
+
+	var templates []models.Template
+	for _, template := range c.Templates {
+		if strings.Contains(template.Name, keywords) || strings.Contains(template.Description, keywords) {
+			templates = append(templates, template)
+		}
+	}
+	return templates, nil
+}
+
 // CheckAuthentication はモック認証チェック
 func (m *SimpleMockClient) CheckAuthentication(ctx context.Context) error {
 	return m.AuthError
@@ -72,8 +101,82 @@ func (m *SimpleMockClient) GetUserTemplates(ctx context.Context) ([]models.Templ
 }
 
 // CreateRepository はモックリポジトリ作成
-func (m *SimpleMockClient) CreateRepository(ctx context.Context, config *models.ProjectConfig) error {
-	return m.CreateError
+func (m *SimpleMockClient) CreateRepository(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error) {
+	if m.CreateError != nil {
+		return nil, m.CreateError
+	}
+	m.Created = append(m.Created, config)
+	return m.repositoryFor(config), nil
+}
+
+// GenerateRepository はモックのテンプレートからのリポジトリ生成
+func (m *SimpleMockClient) GenerateRepository(ctx context.Context, config *models.ProjectConfig) (*RepositoryInfo, error) {
+	if m.CreateError != nil {
+		return nil, m.CreateError
+	}
+	m.Generated = append(m.Generated, config)
+	return m.repositoryFor(config), nil
+}
+
+// repositoryFor は作成されたことにするリポジトリを返す
+func (m *SimpleMockClient) repositoryFor(config *models.ProjectConfig) *RepositoryInfo {
+	if m.Repository != nil {
+		return m.Repository
+	}
+	owner := "testuser"
+	if config.Owner != "" {
+		owner = config.Owner
+	}
+	return &RepositoryInfo{
+		Name:     config.Name,
+		FullName: owner + "/" + config.Name,
+		Owner:    GitHubUser{Login: owner},
+		Private:  config.IsPrivate,
+		HTMLURL:  "https://github.com/" + owner + "/" + config.Name,
+		CloneURL: "https://github.com/" + owner + "/" + config.Name + ".git",
+	}
+}
+
+// ListRepositoryOwners はモックのオーナー一覧取得
+func (m *SimpleMockClient) ListRepositoryOwners(ctx context.Context) ([]string, error) {
+	if m.AuthError != nil {
+		return nil, m.AuthError
+	}
+	if m.Owners == nil {
+		return []string{"testuser"}, nil
+	}
+	return m.Owners, nil
+}
+
+// GrantTeamAccess はモックのチーム権限付与
+func (m *SimpleMockClient) GrantTeamAccess(ctx context.Context, fullName string, grant models.TeamGrant) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Granted = append(m.Granted, fullName+" "+grant.String())
+	return nil
+}
+
+// ApplyRepositorySettings はモックのリポジトリ設定適用（送るはずのリクエストを記録する）
+func (m *SimpleMockClient) ApplyRepositorySettings(ctx context.Context, fullName string, settings *models.RepositorySettings) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Applied = append(m.Applied, RepositorySettingsRequests(fullName, settings)...)
+	return nil
+}
+
+// GetFileContent はモックファイル取得（キーは "owner/repo/path"）
+func (m *SimpleMockClient) GetFileContent(ctx context.Context, fullName, path string) ([]byte, error) {
+	if m.TemplateError != nil {
+		return nil, m.TemplateError
+	}
+	return m.Files[fullName+"/"+path], nil
+}
+
+// Wait はバックグラウンド処理がないので即座に完了する
+func (m *SimpleMockClient) Wait(timeout time.Duration) bool {
+	return true
 }
 
 func TestSimpleMockClient_Scenarios(t *testing.T) {
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// milestoneDueLayout is the layout of milestone due dates
+const milestoneDueLayout = "2006-01-02"
+
+var labelColorPattern = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// Label is an issue label synced onto the new repository
+type Label struct {
+	Name string `yaml:"name" json:"name"`
+	// Color is a hex color such as "d73a4a" (a leading # is allowed)
+	Color       string `yaml:"color" json:"color"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// GetColor returns the color without a leading #, as the API expects it
+func (l Label) GetColor() string {
+	return strings.ToLower(strings.TrimPrefix(l.Color, "#"))
+}
+
+// Milestone is a milestone created in the new repository
+type Milestone struct {
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// DueOn is a date such as 2026-12-31
+	DueOn string `yaml:"due_on,omitempty" json:"due_on,omitempty"`
+}
+
+// Issue is an issue opened in the new repository
+type Issue struct {
+	Title  string   `yaml:"title" json:"title"`
+	Body   string   `yaml:"body,omitempty" json:"body,omitempty"`
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// Milestone is the title of a milestone of the repository
+	Milestone string `yaml:"milestone,omitempty" json:"milestone,omitempty"`
+}
+
+// HasBootstrap reports whether labels, milestones or issues are synced onto the repository
+func (s *RepositorySettings) HasBootstrap() bool {
+	return s != nil && (len(s.Labels) > 0 || s.RemoveDefaultLabels || len(s.Milestones) > 0 || len(s.Issues) > 0)
+}
+
+// validateBootstrap checks the labels, milestones and issues
+func (s *RepositorySettings) validateBootstrap() error {
+	labels := make(map[string]bool)
+	for _, label := range s.Labels {
+		if label.Name == "" {
+			return fmt.Errorf("labels require a name")
+		}
+		if labels[strings.ToLower(label.Name)] {
+			return fmt.Errorf("label '%s' is declared more than once", label.Name)
+		}
+		labels[strings.ToLower(label.Name)] = true
+
+		if !labelColorPattern.MatchString(label.GetColor()) {
+			return fmt.Errorf("invalid color '%s' for label '%s': use a hex color such as d73a4a", label.Color, label.Name)
+		}
+	}
+
+	milestones := make(map[string]bool)
+	for _, milestone := range s.Milestones {
+		if milestone.Title == "" {
+			return fmt.Errorf("milestones require a title")
+		}
+		if milestones[milestone.Title] {
+			return fmt.Errorf("milestone '%s' is declared more than once", milestone.Title)
+		}
+		milestones[milestone.Title] = true
+
+		if milestone.DueOn != "" {
+			if _, err := time.Parse(milestoneDueLayout, milestone.DueOn); err != nil {
+				return fmt.Errorf("invalid due date '%s' for milestone '%s': use YYYY-MM-DD", milestone.DueOn, milestone.Title)
+			}
+		}
+	}
+
+	issues := make(map[string]bool)
+	for _, issue := range s.Issues {
+		if issue.Title == "" {
+			return fmt.Errorf("issues require a title")
+		}
+		if issues[issue.Title] {
+			return fmt.Errorf("issue '%s' is declared more than once", issue.Title)
+		}
+		issues[issue.Title] = true
+
+		if issue.Milestone != "" && !milestones[issue.Milestone] {
+			return fmt.Errorf("issue '%s' uses milestone '%s', which is not declared", issue.Title, issue.Milestone)
+		}
+	}
+	return nil
+}
+
+// GetDueOn returns the due date as the API expects it (empty when there is none)
+func (m Milestone) GetDueOn() string {
+	if m.DueOn == "" {
+		return ""
+	}
+	return m.DueOn + "T00:00:00Z"
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositorySettings_ValidateBootstrap(t *testing.T) {
+	valid := RepositorySettings{
+		Labels:              []Label{{Name: "feature", Color: "#A2EEEF"}},
+		RemoveDefaultLabels: true,
+		Milestones:          []Milestone{{Title: "v1.0", DueOn: "2026-12-31"}},
+		Issues:              []Issue{{Title: "Set up CI", Milestone: "v1.0"}},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.Equal(t, "a2eeef", valid.Labels[0].GetColor())
+	assert.Equal(t, "2026-12-31T00:00:00Z", valid.Milestones[0].GetDueOn())
+
+	tests := []struct {
+		name     string
+		settings RepositorySettings
+		errMsg   string
+	}{
+		{"label without name", RepositorySettings{Labels: []Label{{Color: "ffffff"}}}, "labels require a name"},
+		{"duplicate label", RepositorySettings{Labels: []Label{{Name: "Bug", Color: "ffffff"}, {Name: "bug", Color: "000000"}}}, "declared more than once"},
+		{"invalid color", RepositorySettings{Labels: []Label{{Name: "bug", Color: "red"}}}, "invalid color"},
+		{"invalid due date", RepositorySettings{Milestones: []Milestone{{Title: "v1.0", DueOn: "12/31/2026"}}}, "invalid due date"},
+		{"issue without title", RepositorySettings{Issues: []Issue{{Body: "text"}}}, "issues require a title"},
+		{"unknown milestone", RepositorySettings{Issues: []Issue{{Title: "Set up CI", Milestone: "v9"}}}, "not declared"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.settings.Validate(), tt.errMsg)
+		})
+	}
+}
+
+func TestRepositorySettings_GetDisplaySummary_Bootstrap(t *testing.T) {
+	settings := RepositorySettings{
+		Labels:              []Label{{Name: "feature", Color: "a2eeef"}, {Name: "bug", Color: "d73a4a"}},
+		RemoveDefaultLabels: true,
+		Milestones:          []Milestone{{Title: "v1.0"}},
+		Issues:              []Issue{{Title: "Set up CI"}, {Title: "Write docs"}},
+	}
+	assert.Equal(t, []string{
+		"Labels: feature, bug (default labels removed)",
+		"Milestones: v1.0",
+		"Issues: 2 to open",
+	}, settings.GetDisplaySummary())
+
+	// ラベル指定なしで既定ラベルだけ消す
+	settings = RepositorySettings{RemoveDefaultLabels: true}
+	assert.Equal(t, []string{"Labels: default labels removed"}, settings.GetDisplaySummary())
+}
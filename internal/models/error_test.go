@@ -79,6 +79,11 @@ func TestWizardError_IsRetryable(t *testing.T) {
 			errorType: ErrorTypeProject,
 			expected:  false,
 		},
+		{
+			name:      "template error is not retryable",
+			errorType: ErrorTypeTemplate,
+			expected:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,6 +10,7 @@ const (
 	ErrorTypeGitHub     ErrorType = "GITHUB"
 	ErrorTypeNetwork    ErrorType = "NETWORK"
 	ErrorTypeProject    ErrorType = "PROJECT"
+	ErrorTypeTemplate   ErrorType = "TEMPLATE"
 )
 
 // Legacy ErrorCode constants for backward compatibility
@@ -57,7 +58,7 @@ func (we *WizardError) IsRetryable() bool {
 	switch we.Type {
 	case ErrorTypeNetwork, ErrorTypeGitHub:
 		return true
-	case ErrorTypeValidation, ErrorTypeProject:
+	case ErrorTypeValidation, ErrorTypeProject, ErrorTypeTemplate:
 		return false
 	default:
 		return false
@@ -91,6 +92,15 @@ func NewProjectError(message string, cause error) *WizardError {
 	}
 }
 
+// NewTemplateError creates a template rendering error
+func NewTemplateError(message string, cause error) *WizardError {
+	return &WizardError{
+		Type:    ErrorTypeTemplate,
+		Message: message,
+		Cause:   cause,
+	}
+}
+
 // NewWizardError creates a new WizardError (legacy)
 func NewWizardError(code ErrorCode, message string, cause error) *WizardError {
 	var errorType ErrorType
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockFileName is the file that records how a project was generated
+const LockFileName = ".gh-wizard.lock"
+
+// lockFileHeader is written at the top of every lockfile
+const lockFileHeader = "# Generated by gh-wizard. Used by `gh wizard update`; do not edit by hand.\n"
+
+// ProjectLock records the template, commit and answers a project was generated from
+type ProjectLock struct {
+	Template    string                 `yaml:"template,omitempty"`
+	CloneURL    string                 `yaml:"clone_url,omitempty"`
+	Ref         string                 `yaml:"ref,omitempty"`
+	Path        string                 `yaml:"path,omitempty"`
+	Commit      string                 `yaml:"commit"`
+	Name        string                 `yaml:"name"`
+	Description string                 `yaml:"description,omitempty"`
+	Private     bool                   `yaml:"private,omitempty"`
+	Variables   map[string]interface{} `yaml:"variables,omitempty"`
+	UpdatedAt   time.Time              `yaml:"updated_at"`
+}
+
+// NewProjectLock creates a lock for a project generated from the given template commit
+func NewProjectLock(config *ProjectConfig, commit string) *ProjectLock {
+	lock := &ProjectLock{
+		Commit:      commit,
+		Name:        config.Name,
+		Description: config.Description,
+		Private:     config.IsPrivate,
+		Variables:   config.Variables,
+		UpdatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+
+	if config.Template != nil {
+		lock.Template = config.Template.FullName
+		lock.CloneURL = config.Template.CloneURL
+		lock.Ref = config.Template.Ref
+		lock.Path = config.Template.Path
+	}
+
+	return lock
+}
+
+// ParseProjectLock parses lockfile contents
+func ParseProjectLock(data []byte) (*ProjectLock, error) {
+	var lock ProjectLock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile: %w", err)
+	}
+
+	if lock.Template == "" && lock.CloneURL == "" {
+		return nil, fmt.Errorf("lockfile does not record a template")
+	}
+	if lock.Commit == "" {
+		return nil, fmt.Errorf("lockfile does not record a template commit")
+	}
+
+	return &lock, nil
+}
+
+// Marshal encodes the lockfile contents
+func (l *ProjectLock) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(lockFileHeader), data...), nil
+}
+
+// GetTemplate returns the template the project was generated from
+func (l *ProjectLock) GetTemplate() *Template {
+	name := l.Template
+	if name == "" {
+		name = strings.TrimSuffix(path.Base(strings.TrimSuffix(l.CloneURL, "/")), ".git")
+	} else if _, repo, ok := strings.Cut(name, "/"); ok {
+		name = repo
+	}
+	if l.Path != "" {
+		name = path.Base(l.Path)
+	}
+
+	return &Template{
+		Name:     name,
+		FullName: l.Template,
+		CloneURL: l.CloneURL,
+		Ref:      l.Ref,
+		Path:     l.Path,
+	}
+}
+
+// ToProjectConfig rebuilds the project configuration with the recorded answers.
+// Answers are typed by the manifest, and variables added to the manifest since generation get their defaults.
+func (l *ProjectLock) ToProjectConfig(manifest *TemplateManifest) (*ProjectConfig, error) {
+	variables := make(map[string]interface{}, len(l.Variables))
+	for name, value := range l.Variables {
+		variables[name] = value
+	}
+
+	if manifest != nil {
+		for _, variable := range manifest.Variables {
+			value, ok := variables[variable.Name]
+			if !ok {
+				variables[variable.Name] = variable.DefaultValue()
+				continue
+			}
+
+			normalized, err := variable.NormalizeValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("recorded value of '%s' is no longer valid: %w", variable.Name, err)
+			}
+			variables[variable.Name] = normalized
+		}
+	}
+
+	return &ProjectConfig{
+		Name:        l.Name,
+		Description: l.Description,
+		Template:    l.GetTemplate(),
+		IsPrivate:   l.Private,
+		Variables:   variables,
+		Manifest:    manifest,
+	}, nil
+}
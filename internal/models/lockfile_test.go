@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectLock_RoundTrip(t *testing.T) {
+	config := &ProjectConfig{
+		Name:      "billing",
+		Template:  &Template{FullName: "acme/go-service", CloneURL: "https://github.com/acme/go-service.git"},
+		Variables: map[string]interface{}{"port": 9090, "features": []string{"auth"}},
+	}
+
+	data, err := NewProjectLock(config, "0123456789abcdef").Marshal()
+	require.NoError(t, err)
+
+	lock, err := ParseProjectLock(data)
+	require.NoError(t, err)
+	assert.Equal(t, "acme/go-service", lock.Template)
+	assert.Equal(t, "0123456789abcdef", lock.Commit)
+	assert.Equal(t, "go-service", lock.GetTemplate().Name)
+
+	manifest, err := ParseManifest([]byte(testManifestYAML))
+	require.NoError(t, err)
+
+	restored, err := lock.ToProjectConfig(manifest)
+	require.NoError(t, err)
+	// YAML から読み込んだ値はマニフェストの型に戻る
+	assert.Equal(t, 9090, restored.Variables["port"])
+	assert.Equal(t, []string{"auth"}, restored.Variables["features"])
+	// 生成後に追加された変数はデフォルト値
+	assert.Equal(t, "postgres", restored.Variables["database"])
+}
+
+func TestProjectLock_TemplatePath(t *testing.T) {
+	config := &ProjectConfig{
+		Name:     "billing",
+		Template: &Template{FullName: "acme/templates", Path: "services/go"},
+	}
+
+	data, err := NewProjectLock(config, "0123456789abcdef").Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "path: services/go")
+
+	// サブディレクトリのテンプレートとして復元する
+	lock, err := ParseProjectLock(data)
+	require.NoError(t, err)
+	template := lock.GetTemplate()
+	assert.Equal(t, "go", template.Name)
+	assert.Equal(t, "acme/templates//services/go", template.GetReference())
+}
+
+func TestParseProjectLock_Invalid(t *testing.T) {
+	_, err := ParseProjectLock([]byte("name: billing\ncommit: abc\n"))
+	assert.ErrorContains(t, err, "does not record a template")
+
+	_, err = ParseProjectLock([]byte("template: acme/go-service\n"))
+	assert.ErrorContains(t, err, "does not record a template commit")
+}
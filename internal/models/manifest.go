@@ -0,0 +1,468 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFileName is the manifest file name shipped by template repositories
+const ManifestFileName = ".gh-wizard.yaml"
+
+// VariableType represents the type of a template variable
+type VariableType string
+
+const (
+	VariableTypeString      VariableType = "string"
+	VariableTypeBool        VariableType = "bool"
+	VariableTypeChoice      VariableType = "choice"
+	VariableTypeMultiChoice VariableType = "multi-choice"
+	VariableTypeInt         VariableType = "int"
+)
+
+var variableNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// TemplateVariable represents a variable declared by a template manifest
+type TemplateVariable struct {
+	Name     string       `yaml:"name"`
+	Type     VariableType `yaml:"type"`
+	Prompt   string       `yaml:"prompt"`
+	Help     string       `yaml:"help"`
+	Default  interface{}  `yaml:"default"`
+	Choices  []string     `yaml:"choices"`
+	Validate string       `yaml:"validate"`
+	Required bool         `yaml:"required"`
+}
+
+// ManifestDelimiters represents custom template action delimiters
+type ManifestDelimiters struct {
+	Left  string `yaml:"left"`
+	Right string `yaml:"right"`
+}
+
+// ManifestCondition includes a file or directory only when If renders truthy
+type ManifestCondition struct {
+	Path string `yaml:"path"`
+	If   string `yaml:"if"`
+}
+
+// ManifestHook represents a command declared by the template
+type ManifestHook struct {
+	Name     string `yaml:"name"`
+	Run      string `yaml:"run"`
+	Timeout  string `yaml:"timeout"`
+	Required bool   `yaml:"required"`
+}
+
+// ManifestHooks groups hooks by stage
+type ManifestHooks struct {
+	PreGenerate []ManifestHook `yaml:"pre_generate"`
+	PostCreate  []ManifestHook `yaml:"post_create"`
+}
+
+// All returns hooks of every stage in execution order
+func (h ManifestHooks) All() []ManifestHook {
+	hooks := make([]ManifestHook, 0, len(h.PreGenerate)+len(h.PostCreate))
+	hooks = append(hooks, h.PreGenerate...)
+	return append(hooks, h.PostCreate...)
+}
+
+// ManifestTemplate declares a template kept in a subdirectory of a collection repository
+type ManifestTemplate struct {
+	Name        string   `yaml:"name"`
+	Path        string   `yaml:"path"`
+	Description string   `yaml:"description"`
+	Topics      []string `yaml:"topics"`
+}
+
+// TemplateManifest represents the manifest file of a template repository
+type TemplateManifest struct {
+	Variables  []TemplateVariable  `yaml:"variables"`
+	Delimiters *ManifestDelimiters `yaml:"delimiters"`
+	Ignore     []string            `yaml:"ignore"`
+	Conditions []ManifestCondition `yaml:"conditions"`
+	Hooks      ManifestHooks       `yaml:"hooks"`
+	// Templates makes the repository a collection whose subdirectories are offered as separate templates
+	Templates []ManifestTemplate `yaml:"templates"`
+	// RepoSettings are applied to the GitHub repository created from the template
+	RepoSettings *RepositorySettings `yaml:"repo_settings"`
+}
+
+// ParseManifest parses manifest YAML data
+func ParseManifest(data []byte) (*TemplateManifest, error) {
+	var manifest TemplateManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	for i := range manifest.Variables {
+		if manifest.Variables[i].Type == "" {
+			manifest.Variables[i].Type = VariableTypeString
+		}
+	}
+
+	for i := range manifest.Templates {
+		subdir, err := CleanTemplatePath(manifest.Templates[i].Path)
+		if err != nil {
+			return nil, fmt.Errorf("templates: %w", err)
+		}
+		manifest.Templates[i].Path = subdir
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// Validate checks the validity of the manifest
+func (m *TemplateManifest) Validate() error {
+	if m.Delimiters != nil && (m.Delimiters.Left == "" || m.Delimiters.Right == "") {
+		return fmt.Errorf("both left and right delimiters are required")
+	}
+
+	for _, condition := range m.Conditions {
+		if condition.Path == "" || condition.If == "" {
+			return fmt.Errorf("conditions require both path and if")
+		}
+	}
+
+	for _, hook := range m.Hooks.PreGenerate {
+		if err := hook.Validate(); err != nil {
+			return fmt.Errorf("pre_generate hook: %w", err)
+		}
+	}
+	for _, hook := range m.Hooks.PostCreate {
+		if err := hook.Validate(); err != nil {
+			return fmt.Errorf("post_create hook: %w", err)
+		}
+	}
+
+	if m.RepoSettings != nil {
+		if err := m.RepoSettings.Validate(); err != nil {
+			return fmt.Errorf("repo_settings: %w", err)
+		}
+	}
+
+	paths := make(map[string]bool)
+	for _, template := range m.Templates {
+		if template.Path == "" {
+			return fmt.Errorf("templates require a path")
+		}
+		if paths[template.Path] {
+			return fmt.Errorf("template path '%s' is declared more than once", template.Path)
+		}
+		paths[template.Path] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, variable := range m.Variables {
+		if !variableNamePattern.MatchString(variable.Name) {
+			return fmt.Errorf("invalid variable name '%s'", variable.Name)
+		}
+		if seen[variable.Name] {
+			return fmt.Errorf("variable '%s' is declared more than once", variable.Name)
+		}
+		seen[variable.Name] = true
+
+		if err := variable.validateDefinition(); err != nil {
+			return fmt.Errorf("variable '%s': %w", variable.Name, err)
+		}
+	}
+	return nil
+}
+
+// GetDelimiters returns the template action delimiters
+func (m *TemplateManifest) GetDelimiters() (string, string) {
+	if m.Delimiters == nil {
+		return "{{", "}}"
+	}
+	return m.Delimiters.Left, m.Delimiters.Right
+}
+
+// GetVariable returns the variable with the given name
+func (m *TemplateManifest) GetVariable(name string) *TemplateVariable {
+	for i := range m.Variables {
+		if m.Variables[i].Name == name {
+			return &m.Variables[i]
+		}
+	}
+	return nil
+}
+
+// ResolveValues converts raw key=value pairs into typed variable values, filling in defaults
+func (m *TemplateManifest) ResolveValues(raw map[string]string) (map[string]interface{}, error) {
+	for key := range raw {
+		if m.GetVariable(key) == nil {
+			return nil, fmt.Errorf("variable '%s' is not declared by the template", key)
+		}
+	}
+
+	values := make(map[string]interface{}, len(m.Variables))
+	for _, variable := range m.Variables {
+		var value interface{}
+		if rawValue, ok := raw[variable.Name]; ok {
+			parsed, err := variable.ParseValue(rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("variable '%s': %w", variable.Name, err)
+			}
+			value = parsed
+		} else {
+			value = variable.DefaultValue()
+		}
+
+		if err := variable.ValidateValue(value); err != nil {
+			return nil, fmt.Errorf("variable '%s': %w", variable.Name, err)
+		}
+		values[variable.Name] = value
+	}
+
+	return values, nil
+}
+
+// Validate checks the validity of the hook definition
+func (h ManifestHook) Validate() error {
+	if strings.TrimSpace(h.Run) == "" {
+		return fmt.Errorf("run is required")
+	}
+	if h.Timeout != "" {
+		if _, err := time.ParseDuration(h.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout '%s'", h.Timeout)
+		}
+	}
+	return nil
+}
+
+// GetName returns the display name of the hook
+func (h ManifestHook) GetName() string {
+	if h.Name != "" {
+		return h.Name
+	}
+	return h.Run
+}
+
+// GetTimeout returns the hook timeout (0 means no timeout beyond the parent context)
+func (h ManifestHook) GetTimeout() time.Duration {
+	timeout, _ := time.ParseDuration(h.Timeout)
+	return timeout
+}
+
+// GetPrompt returns the prompt message of the variable
+func (v TemplateVariable) GetPrompt() string {
+	if v.Prompt != "" {
+		return v.Prompt
+	}
+	return v.Name
+}
+
+// ParseValue converts a raw string into a value of the variable type
+func (v TemplateVariable) ParseValue(raw string) (interface{}, error) {
+	switch v.Type {
+	case VariableTypeBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a boolean", raw)
+		}
+		return value, nil
+	case VariableTypeInt:
+		value, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not an integer", raw)
+		}
+		return value, nil
+	case VariableTypeMultiChoice:
+		values := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values, nil
+	default:
+		return raw, nil
+	}
+}
+
+// ValidateValue checks a typed value against the variable definition
+func (v TemplateVariable) ValidateValue(value interface{}) error {
+	switch v.Type {
+	case VariableTypeString:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string value")
+		}
+		if strings.TrimSpace(str) == "" {
+			if v.Required {
+				return fmt.Errorf("value is required")
+			}
+			return nil
+		}
+		return v.matchPattern(str)
+	case VariableTypeBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected a boolean value")
+		}
+	case VariableTypeInt:
+		number, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("expected an integer value")
+		}
+		return v.matchPattern(strconv.Itoa(number))
+	case VariableTypeChoice:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string value")
+		}
+		if str == "" && !v.Required {
+			return nil
+		}
+		if !v.hasChoice(str) {
+			return fmt.Errorf("'%s' is not one of: %s", str, strings.Join(v.Choices, ", "))
+		}
+	case VariableTypeMultiChoice:
+		items, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("expected a list value")
+		}
+		if v.Required && len(items) == 0 {
+			return fmt.Errorf("at least one choice is required")
+		}
+		for _, item := range items {
+			if !v.hasChoice(item) {
+				return fmt.Errorf("'%s' is not one of: %s", item, strings.Join(v.Choices, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultValue returns the default value converted to the variable type
+func (v TemplateVariable) DefaultValue() interface{} {
+	switch v.Type {
+	case VariableTypeBool:
+		if value, ok := v.Default.(bool); ok {
+			return value
+		}
+		return false
+	case VariableTypeInt:
+		if value, ok := v.Default.(int); ok {
+			return value
+		}
+		return 0
+	case VariableTypeMultiChoice:
+		values := []string{}
+		if items, ok := v.Default.([]interface{}); ok {
+			for _, item := range items {
+				values = append(values, fmt.Sprint(item))
+			}
+		}
+		return values
+	default:
+		if v.Default == nil {
+			return ""
+		}
+		return fmt.Sprint(v.Default)
+	}
+}
+
+// NormalizeValue converts a decoded JSON or YAML value into the variable type
+func (v TemplateVariable) NormalizeValue(value interface{}) (interface{}, error) {
+	switch v.Type {
+	case VariableTypeInt:
+		if number, ok := value.(float64); ok && number == float64(int(number)) {
+			value = int(number)
+		}
+	case VariableTypeMultiChoice:
+		if items, ok := value.([]interface{}); ok {
+			values := make([]string, 0, len(items))
+			for _, item := range items {
+				values = append(values, fmt.Sprint(item))
+			}
+			value = values
+		}
+	}
+
+	if err := v.ValidateValue(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// validateDefinition checks the variable definition itself
+func (v TemplateVariable) validateDefinition() error {
+	switch v.Type {
+	case VariableTypeString, VariableTypeBool, VariableTypeInt:
+	case VariableTypeChoice, VariableTypeMultiChoice:
+		if len(v.Choices) == 0 {
+			return fmt.Errorf("choices are required for type '%s'", v.Type)
+		}
+	default:
+		return fmt.Errorf("unknown type '%s'", v.Type)
+	}
+
+	if v.Validate != "" {
+		if _, err := regexp.Compile(v.Validate); err != nil {
+			return fmt.Errorf("invalid validation pattern: %w", err)
+		}
+	}
+
+	if v.Default != nil {
+		// DefaultValue turns a default of another type into the zero value, so reject it here
+		if err := v.checkDefaultType(); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+
+		// Required only applies to user input, not to the default itself
+		v.Required = false
+		if err := v.ValidateValue(v.DefaultValue()); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkDefaultType checks that the default is written as the variable type
+func (v TemplateVariable) checkDefaultType() error {
+	switch v.Type {
+	case VariableTypeBool:
+		if _, ok := v.Default.(bool); !ok {
+			return fmt.Errorf("'%v' is not a boolean", v.Default)
+		}
+	case VariableTypeInt:
+		if _, ok := v.Default.(int); !ok {
+			return fmt.Errorf("'%v' is not an integer", v.Default)
+		}
+	case VariableTypeMultiChoice:
+		if _, ok := v.Default.([]interface{}); !ok {
+			return fmt.Errorf("'%v' is not a list", v.Default)
+		}
+	}
+	return nil
+}
+
+// matchPattern checks the value against the validation regex
+func (v TemplateVariable) matchPattern(value string) error {
+	if v.Validate == "" {
+		return nil
+	}
+	if !regexp.MustCompile(v.Validate).MatchString(value) {
+		return fmt.Errorf("'%s' does not match pattern %s", value, v.Validate)
+	}
+	return nil
+}
+
+// hasChoice checks if the value is one of the declared choices
+func (v TemplateVariable) hasChoice(value string) bool {
+	for _, choice := range v.Choices {
+		if choice == value {
+			return true
+		}
+	}
+	return false
+}
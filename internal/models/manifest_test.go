@@ -0,0 +1,213 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifestYAML = `
+variables:
+  - name: service_name
+    prompt: "Service name?"
+    validate: "^[a-z][a-z0-9-]*$"
+    required: true
+  - name: use_ci
+    type: bool
+    default: true
+  - name: database
+    type: choice
+    choices: [postgres, mysql, none]
+    default: postgres
+  - name: features
+    type: multi-choice
+    choices: [auth, metrics, tracing]
+    default: [metrics]
+  - name: port
+    type: int
+    default: 8080
+`
+
+func TestParseManifest(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifestYAML))
+	require.NoError(t, err)
+	require.Len(t, manifest.Variables, 5)
+
+	// type 未指定は string として扱う
+	assert.Equal(t, VariableTypeString, manifest.Variables[0].Type)
+	assert.Equal(t, "Service name?", manifest.Variables[0].GetPrompt())
+	assert.Equal(t, "use_ci", manifest.Variables[1].GetPrompt())
+}
+
+func TestParseManifest_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{
+			name:   "invalid variable name",
+			yaml:   "variables:\n  - name: my-var\n",
+			errMsg: "invalid variable name",
+		},
+		{
+			name:   "duplicate variable",
+			yaml:   "variables:\n  - name: a\n  - name: a\n",
+			errMsg: "declared more than once",
+		},
+		{
+			name:   "unknown type",
+			yaml:   "variables:\n  - name: a\n    type: float\n",
+			errMsg: "unknown type",
+		},
+		{
+			name:   "choice without choices",
+			yaml:   "variables:\n  - name: a\n    type: choice\n",
+			errMsg: "choices are required",
+		},
+		{
+			name:   "invalid regex",
+			yaml:   "variables:\n  - name: a\n    validate: \"[\"\n",
+			errMsg: "invalid validation pattern",
+		},
+		{
+			name:   "invalid repo settings",
+			yaml:   "repo_settings:\n  merge_strategies: [fast-forward]\n",
+			errMsg: "repo_settings: invalid merge strategy",
+		},
+		{
+			name:   "hook without command",
+			yaml:   "hooks:\n  post_create:\n    - name: install\n",
+			errMsg: "run is required",
+		},
+		{
+			name:   "hook with invalid timeout",
+			yaml:   "hooks:\n  post_create:\n    - run: npm install\n      timeout: soon\n",
+			errMsg: "invalid timeout",
+		},
+		{
+			name:   "template outside the repository",
+			yaml:   "templates:\n  - name: secrets\n    path: ../secrets\n",
+			errMsg: "must be a subdirectory",
+		},
+		{
+			name:   "duplicate template path",
+			yaml:   "templates:\n  - path: web\n  - path: ./web\n",
+			errMsg: "declared more than once",
+		},
+		{
+			name:   "default not in choices",
+			yaml:   "variables:\n  - name: a\n    type: choice\n    choices: [x]\n    default: y\n",
+			errMsg: "invalid default",
+		},
+		{
+			name:   "string default for bool",
+			yaml:   "variables:\n  - name: a\n    type: bool\n    default: \"yes\"\n",
+			errMsg: "invalid default: 'yes' is not a boolean",
+		},
+		{
+			name:   "non-numeric default for int",
+			yaml:   "variables:\n  - name: a\n    type: int\n    default: eighty\n",
+			errMsg: "invalid default: 'eighty' is not an integer",
+		},
+		{
+			name:   "scalar default for multi choice",
+			yaml:   "variables:\n  - name: a\n    type: multi-choice\n    choices: [x]\n    default: x\n",
+			errMsg: "invalid default: 'x' is not a list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseManifest([]byte(tt.yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestTemplateManifest_ResolveValues(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifestYAML))
+	require.NoError(t, err)
+
+	t.Run("defaults and overrides", func(t *testing.T) {
+		values, err := manifest.ResolveValues(map[string]string{
+			"service_name": "billing",
+			"use_ci":       "false",
+			"features":     "auth, tracing",
+			"port":         "9090",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "billing", values["service_name"])
+		assert.Equal(t, false, values["use_ci"])
+		assert.Equal(t, "postgres", values["database"])
+		assert.Equal(t, []string{"auth", "tracing"}, values["features"])
+		assert.Equal(t, 9090, values["port"])
+	})
+
+	tests := []struct {
+		name   string
+		raw    map[string]string
+		errMsg string
+	}{
+		{
+			name:   "required value missing",
+			raw:    map[string]string{},
+			errMsg: "value is required",
+		},
+		{
+			name:   "pattern mismatch",
+			raw:    map[string]string{"service_name": "Billing"},
+			errMsg: "does not match pattern",
+		},
+		{
+			name:   "unknown variable",
+			raw:    map[string]string{"service_name": "billing", "unknown": "x"},
+			errMsg: "not declared",
+		},
+		{
+			name:   "invalid bool",
+			raw:    map[string]string{"service_name": "billing", "use_ci": "maybe"},
+			errMsg: "not a boolean",
+		},
+		{
+			name:   "invalid choice",
+			raw:    map[string]string{"service_name": "billing", "database": "oracle"},
+			errMsg: "is not one of",
+		},
+		{
+			name:   "invalid int",
+			raw:    map[string]string{"service_name": "billing", "port": "http"},
+			errMsg: "not an integer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := manifest.ResolveValues(tt.raw)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestTemplateVariable_NormalizeValue(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifestYAML))
+	require.NoError(t, err)
+
+	// JSON の数値や配列をマニフェストの型に変換する
+	port, err := manifest.GetVariable("port").NormalizeValue(float64(9090))
+	require.NoError(t, err)
+	assert.Equal(t, 9090, port)
+
+	features, err := manifest.GetVariable("features").NormalizeValue([]interface{}{"auth"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"auth"}, features)
+
+	_, err = manifest.GetVariable("port").NormalizeValue(1.5)
+	assert.Error(t, err)
+	_, err = manifest.GetVariable("database").NormalizeValue("oracle")
+	assert.Error(t, err)
+}
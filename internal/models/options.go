@@ -0,0 +1,165 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Visibilities are the visibilities a GitHub repository can be created with
+var Visibilities = []string{"public", "private", "internal"}
+
+// maxTopics is the number of topics GitHub allows on a repository
+const maxTopics = 20
+
+// topicPattern matches a GitHub topic: lowercase letters, numbers and hyphens, up to 50 characters
+var topicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// RepositoryOptions are properties of the GitHub repository set when it is created.
+// Unset fields keep GitHub's defaults. The wiki is configured by RepositorySettings.HasWiki only.
+type RepositoryOptions struct {
+	// Visibility overrides IsPrivate; internal is only available in enterprise organizations
+	Visibility  string   `yaml:"visibility,omitempty" json:"visibility,omitempty"`
+	Homepage    string   `yaml:"homepage,omitempty" json:"homepage,omitempty"`
+	Topics      []string `yaml:"topics,omitempty" json:"topics,omitempty"`
+	HasIssues   *bool    `yaml:"has_issues,omitempty" json:"has_issues,omitempty"`
+	HasProjects *bool    `yaml:"has_projects,omitempty" json:"has_projects,omitempty"`
+	// CustomProperties are values of the organization's custom properties, by property name
+	CustomProperties map[string]string `yaml:"custom_properties,omitempty" json:"custom_properties,omitempty"`
+}
+
+// Validate checks the validity of the options
+func (o RepositoryOptions) Validate() error {
+	if o.Visibility != "" && !containsString(Visibilities, o.Visibility) {
+		return fmt.Errorf("invalid visibility '%s': use one of %s", o.Visibility, strings.Join(Visibilities, ", "))
+	}
+
+	if o.Homepage != "" {
+		u, err := url.Parse(o.Homepage)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid homepage '%s': use an http or https URL", o.Homepage)
+		}
+	}
+
+	if len(o.Topics) > maxTopics {
+		return fmt.Errorf("a repository can have at most %d topics", maxTopics)
+	}
+	for _, topic := range o.Topics {
+		if !topicPattern.MatchString(topic) {
+			return fmt.Errorf("invalid topic '%s': use lowercase letters, numbers and hyphens, up to 50 characters", topic)
+		}
+	}
+
+	for name := range o.CustomProperties {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("custom property name is required")
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty reports whether the options change nothing
+func (o RepositoryOptions) IsEmpty() bool {
+	return o.Visibility == "" && o.Homepage == "" && len(o.Topics) == 0 &&
+		o.HasIssues == nil && o.HasProjects == nil && len(o.CustomProperties) == 0
+}
+
+// ParseTopics splits a comma or space separated list of topics, lowercasing them
+func ParseTopics(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	var topics []string
+	for _, field := range fields {
+		topics = append(topics, strings.ToLower(field))
+	}
+	return topics
+}
+
+// ParseCustomProperty parses "name=value"
+func ParseCustomProperty(value string) (string, string, error) {
+	name, propertyValue, ok := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("invalid custom property '%s': use name=value", value)
+	}
+	return name, strings.TrimSpace(propertyValue), nil
+}
+
+// MergeRepositoryOptions combines options in increasing precedence: fields set by later options
+// replace earlier ones, while topics and custom properties are added to the earlier ones.
+func MergeRepositoryOptions(options ...RepositoryOptions) RepositoryOptions {
+	var merged RepositoryOptions
+	for _, o := range options {
+		if o.Visibility != "" {
+			merged.Visibility = o.Visibility
+		}
+		if o.Homepage != "" {
+			merged.Homepage = o.Homepage
+		}
+		for _, topic := range o.Topics {
+			if !containsString(merged.Topics, topic) {
+				merged.Topics = append(merged.Topics, topic)
+			}
+		}
+		if o.HasIssues != nil {
+			merged.HasIssues = o.HasIssues
+		}
+		if o.HasProjects != nil {
+			merged.HasProjects = o.HasProjects
+		}
+		for name, value := range o.CustomProperties {
+			if merged.CustomProperties == nil {
+				merged.CustomProperties = make(map[string]string)
+			}
+			merged.CustomProperties[name] = value
+		}
+	}
+	return merged
+}
+
+// GetDisplaySummary returns one line per option for display; the visibility is shown with the repository
+func (o RepositoryOptions) GetDisplaySummary() []string {
+	var lines []string
+	if len(o.Topics) > 0 {
+		lines = append(lines, fmt.Sprintf("🏷️  Topics: %s", strings.Join(o.Topics, ", ")))
+	}
+	if o.Homepage != "" {
+		lines = append(lines, fmt.Sprintf("🏠 Homepage: %s", o.Homepage))
+	}
+
+	var features []string
+	for _, feature := range []struct {
+		name    string
+		enabled *bool
+	}{
+		{"Issues", o.HasIssues},
+		{"Projects", o.HasProjects},
+	} {
+		if feature.enabled != nil {
+			features = append(features, fmt.Sprintf("%s %s", feature.name, strings.ToLower(enabledLabel(*feature.enabled))))
+		}
+	}
+	if len(features) > 0 {
+		lines = append(lines, fmt.Sprintf("🧩 Features: %s", strings.Join(features, ", ")))
+	}
+
+	if len(o.CustomProperties) > 0 {
+		names := make([]string, 0, len(o.CustomProperties))
+		for name := range o.CustomProperties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		properties := make([]string, len(names))
+		for i, name := range names {
+			properties[i] = name + "=" + o.CustomProperties[name]
+		}
+		lines = append(lines, fmt.Sprintf("📋 Properties: %s", strings.Join(properties, ", ")))
+	}
+	return lines
+}
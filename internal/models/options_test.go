@@ -0,0 +1,88 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryOptions_Validate(t *testing.T) {
+	valid := RepositoryOptions{
+		Visibility:       "internal",
+		Homepage:         "https://example.com/docs",
+		Topics:           []string{"go", "cli-tools"},
+		CustomProperties: map[string]string{"team": "platform"},
+	}
+	assert.NoError(t, valid.Validate())
+
+	for _, options := range []RepositoryOptions{
+		{Visibility: "secret"},
+		{Homepage: "example.com"},
+		{Homepage: "ftp://example.com"},
+		{Topics: []string{"Go"}},
+		{Topics: []string{"-go"}},
+		{Topics: make([]string, 21)},
+		{CustomProperties: map[string]string{" ": "platform"}},
+	} {
+		assert.Error(t, options.Validate(), "%+v", options)
+	}
+}
+
+func TestParseTopics(t *testing.T) {
+	assert.Equal(t, []string{"go", "cli", "tools"}, ParseTopics("Go, cli  tools,"))
+	assert.Nil(t, ParseTopics(" "))
+}
+
+func TestParseCustomProperty(t *testing.T) {
+	name, value, err := ParseCustomProperty("team = platform")
+	require.NoError(t, err)
+	assert.Equal(t, "team", name)
+	assert.Equal(t, "platform", value)
+
+	// 値は空でもよい
+	_, value, err = ParseCustomProperty("team=")
+	require.NoError(t, err)
+	assert.Empty(t, value)
+
+	for _, property := range []string{"team", "=platform"} {
+		_, _, err := ParseCustomProperty(property)
+		assert.Error(t, err, property)
+	}
+}
+
+func TestMergeRepositoryOptions(t *testing.T) {
+	enabled, disabled := true, false
+	merged := MergeRepositoryOptions(
+		RepositoryOptions{Visibility: "private", Topics: []string{"go"}, HasProjects: &disabled, CustomProperties: map[string]string{"team": "platform", "tier": "1"}},
+		RepositoryOptions{},
+		RepositoryOptions{Visibility: "internal", Topics: []string{"cli", "go"}, HasProjects: &enabled, CustomProperties: map[string]string{"tier": "2"}},
+	)
+
+	// 単一の値は後勝ち、トピックとカスタムプロパティは足し合わせる
+	assert.Equal(t, "internal", merged.Visibility)
+	assert.Equal(t, []string{"go", "cli"}, merged.Topics)
+	assert.True(t, *merged.HasProjects)
+	assert.Equal(t, map[string]string{"team": "platform", "tier": "2"}, merged.CustomProperties)
+
+	assert.True(t, MergeRepositoryOptions().IsEmpty())
+}
+
+func TestRepositoryOptions_GetDisplaySummary(t *testing.T) {
+	disabled := false
+	options := RepositoryOptions{
+		Homepage:         "https://example.com",
+		Topics:           []string{"go", "cli"},
+		HasIssues:        &disabled,
+		HasProjects:      &disabled,
+		CustomProperties: map[string]string{"tier": "1", "team": "platform"},
+	}
+
+	assert.Equal(t, []string{
+		"🏷️  Topics: go, cli",
+		"🏠 Homepage: https://example.com",
+		"🧩 Features: Issues disabled, Projects disabled",
+		"📋 Properties: team=platform, tier=1",
+	}, options.GetDisplaySummary())
+	assert.Empty(t, RepositoryOptions{}.GetDisplaySummary())
+}
@@ -1,14 +1,25 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type ProjectConfig struct {
-	Name         string    `json:"name"`
-	Description  string    `json:"description"`
-	Template     *Template `json:"template,omitempty"`
-	CreateGitHub bool      `json:"create_github"`
-	IsPrivate    bool      `json:"is_private"`
-	LocalPath    string    `json:"local_path"`
+	Name               string                 `json:"name"`
+	Description        string                 `json:"description"`
+	Template           *Template              `json:"template,omitempty"`
+	CreateGitHub       bool                   `json:"create_github"`
+	IsPrivate          bool                   `json:"is_private"`
+	Owner              string                 `json:"owner,omitempty"`
+	GenerateOnGitHub   bool                   `json:"generate_on_github,omitempty"`
+	IncludeAllBranches bool                   `json:"include_all_branches,omitempty"`
+	Teams              []TeamGrant            `json:"teams,omitempty"`
+	Repository         RepositoryOptions      `json:"repository"`
+	RepoSettings       *RepositorySettings    `json:"repo_settings,omitempty"`
+	LocalPath          string                 `json:"local_path"`
+	Variables          map[string]interface{} `json:"variables,omitempty"`
+	Manifest           *TemplateManifest      `json:"-"`
 }
 
 // Validate checks the validity of configuration values
@@ -25,6 +36,40 @@ func (pc *ProjectConfig) Validate() error {
 		return fmt.Errorf("description must be at most 500 characters")
 	}
 
+	if pc.GenerateOnGitHub {
+		if !pc.CreateGitHub {
+			return fmt.Errorf("generating from a template on GitHub requires creating a GitHub repository")
+		}
+		if pc.Template == nil || pc.Template.FullName == "" || pc.Template.IsLocal() || pc.Template.GetSource() == SourceGit {
+			return fmt.Errorf("only GitHub templates can be generated on GitHub")
+		}
+		if pc.Template.Path != "" {
+			return fmt.Errorf("a template in a repository subdirectory cannot be generated on GitHub")
+		}
+		if pc.Template.Ref != "" {
+			return fmt.Errorf("a template ref cannot be generated on GitHub: it copies the default branch")
+		}
+	} else if pc.IncludeAllBranches {
+		return fmt.Errorf("including all branches requires generating from the template on GitHub")
+	}
+
+	if len(pc.Teams) > 0 && (!pc.CreateGitHub || pc.Owner == "") {
+		return fmt.Errorf("team access requires creating a GitHub repository owned by an organization")
+	}
+
+	if err := pc.Repository.Validate(); err != nil {
+		return err
+	}
+	if !pc.Repository.IsEmpty() && !pc.CreateGitHub {
+		return fmt.Errorf("repository options require creating a GitHub repository")
+	}
+	if pc.Repository.Visibility == "internal" && pc.Owner == "" {
+		return fmt.Errorf("internal visibility requires a repository owned by an organization")
+	}
+	if len(pc.Repository.CustomProperties) > 0 && pc.Owner == "" {
+		return fmt.Errorf("custom properties require a repository owned by an organization")
+	}
+
 	return nil
 }
 
@@ -51,6 +96,18 @@ func (pc *ProjectConfig) GetGitHubCreateCommand() []string {
 	return args
 }
 
+// GetVisibility returns the visibility of the GitHub repository: Repository.Visibility when set,
+// otherwise private or public following IsPrivate
+func (pc *ProjectConfig) GetVisibility() string {
+	if pc.Repository.Visibility != "" {
+		return pc.Repository.Visibility
+	}
+	if pc.IsPrivate {
+		return "private"
+	}
+	return "public"
+}
+
 // GetLocalCreatePath returns the local creation path
 func (pc *ProjectConfig) GetLocalCreatePath() string {
 	if pc.LocalPath != "" {
@@ -64,6 +121,24 @@ func (pc *ProjectConfig) HasTemplate() bool {
 	return pc.Template != nil
 }
 
+// GetTemplateData returns the data available to template files
+func (pc *ProjectConfig) GetTemplateData() map[string]interface{} {
+	vars := make(map[string]interface{}, len(pc.Variables))
+	data := make(map[string]interface{}, len(pc.Variables)+4)
+	for name, value := range pc.Variables {
+		vars[name] = value
+		data[name] = value
+	}
+
+	// Built-in values take precedence; shadowed variables remain reachable via .Vars
+	data["Name"] = pc.Name
+	data["Description"] = pc.Description
+	data["Private"] = pc.IsPrivate
+	data["Vars"] = vars
+
+	return data
+}
+
 // GetDisplaySummary returns a display summary of configuration
 func (pc *ProjectConfig) GetDisplaySummary() []string {
 	summary := []string{
@@ -75,17 +150,42 @@ func (pc *ProjectConfig) GetDisplaySummary() []string {
 	}
 
 	if pc.Template != nil {
-		summary = append(summary, fmt.Sprintf("📚 Template: %s", pc.Template.FullName))
+		summary = append(summary, fmt.Sprintf("📚 Template: %s", pc.Template.GetReference()))
 	} else {
 		summary = append(summary, "📚 Template: None")
 	}
 
 	if pc.CreateGitHub {
 		visibility := "🌐 Public"
-		if pc.IsPrivate {
+		switch pc.GetVisibility() {
+		case "private":
 			visibility = "🔒 Private"
+		case "internal":
+			visibility = "🏢 Internal"
+		}
+		action := "Create"
+		if pc.GenerateOnGitHub {
+			action = "Generate from template"
+			if pc.IncludeAllBranches {
+				visibility += ", all branches"
+			}
+		}
+		if pc.Owner != "" {
+			action += " in " + pc.Owner
+		}
+		summary = append(summary, fmt.Sprintf("🐙 GitHub: %s (%s)", action, visibility))
+
+		if len(pc.Teams) > 0 {
+			teams := make([]string, len(pc.Teams))
+			for i, grant := range pc.Teams {
+				teams[i] = grant.String()
+			}
+			summary = append(summary, fmt.Sprintf("👥 Teams: %s", strings.Join(teams, ", ")))
+		}
+		summary = append(summary, pc.Repository.GetDisplaySummary()...)
+		for _, line := range pc.RepoSettings.GetDisplaySummary() {
+			summary = append(summary, "⚙️  "+line)
 		}
-		summary = append(summary, fmt.Sprintf("🐙 GitHub: Create (%s)", visibility))
 	} else {
 		summary = append(summary, "🐙 GitHub: Do not create")
 	}
@@ -48,6 +48,90 @@ func TestProjectConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "description must be at most 500 characters",
 		},
+		{
+			name: "generate on GitHub",
+			config: ProjectConfig{
+				Name:               "test-project",
+				Template:           &Template{Name: "go-template", FullName: "acme/go-template"},
+				CreateGitHub:       true,
+				GenerateOnGitHub:   true,
+				IncludeAllBranches: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "generate without GitHub repository",
+			config: ProjectConfig{
+				Name:             "test-project",
+				Template:         &Template{Name: "go-template", FullName: "acme/go-template"},
+				GenerateOnGitHub: true,
+			},
+			wantErr: true,
+			errMsg:  "requires creating a GitHub repository",
+		},
+		{
+			name: "generate from git URL template",
+			config: ProjectConfig{
+				Name:             "test-project",
+				Template:         &Template{Name: "service", CloneURL: "https://gitea.example.com/platform/service.git", Source: SourceGit},
+				CreateGitHub:     true,
+				GenerateOnGitHub: true,
+			},
+			wantErr: true,
+			errMsg:  "only GitHub templates can be generated on GitHub",
+		},
+		{
+			name: "generate template subdirectory",
+			config: ProjectConfig{
+				Name:             "test-project",
+				Template:         &Template{Name: "go", FullName: "acme/templates", Path: "services/go"},
+				CreateGitHub:     true,
+				GenerateOnGitHub: true,
+			},
+			wantErr: true,
+			errMsg:  "subdirectory",
+		},
+		{
+			name: "generate pinned template",
+			config: ProjectConfig{
+				Name:             "test-project",
+				Template:         &Template{Name: "go-template", FullName: "acme/go-template", Ref: "v1.0.0"},
+				CreateGitHub:     true,
+				GenerateOnGitHub: true,
+			},
+			wantErr: true,
+			errMsg:  "template ref",
+		},
+		{
+			name: "all branches without generate",
+			config: ProjectConfig{
+				Name:               "test-project",
+				CreateGitHub:       true,
+				IncludeAllBranches: true,
+			},
+			wantErr: true,
+			errMsg:  "requires generating",
+		},
+		{
+			name: "teams under an organization",
+			config: ProjectConfig{
+				Name:         "test-project",
+				CreateGitHub: true,
+				Owner:        "acme",
+				Teams:        []TeamGrant{{Team: "backend", Permission: "push"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "teams without owner",
+			config: ProjectConfig{
+				Name:         "test-project",
+				CreateGitHub: true,
+				Teams:        []TeamGrant{{Team: "backend", Permission: "push"}},
+			},
+			wantErr: true,
+			errMsg:  "team access requires",
+		},
 		{
 			name: "validate edge case",
 			config: ProjectConfig{
@@ -156,6 +240,101 @@ func TestProjectConfig_GetDIsplaySummary(t *testing.T) {
 	assert.Contains(t, summary[4], "./test-project")
 }
 
+func TestProjectConfig_GetDisplaySummary_Generate(t *testing.T) {
+	config := ProjectConfig{
+		Name:               "billing",
+		Template:           &Template{Name: "go-template", FullName: "acme/go-template"},
+		CreateGitHub:       true,
+		IsPrivate:          true,
+		Owner:              "acme",
+		GenerateOnGitHub:   true,
+		IncludeAllBranches: true,
+	}
+
+	assert.Contains(t, config.GetDisplaySummary(), "🐙 GitHub: Generate from template in acme (🔒 Private, all branches)")
+}
+
+func TestProjectConfig_GetDisplaySummary_Teams(t *testing.T) {
+	config := ProjectConfig{
+		Name:         "billing",
+		CreateGitHub: true,
+		Owner:        "acme",
+		Teams:        []TeamGrant{{Team: "backend", Permission: "push"}, {Team: "sre", Permission: "admin"}},
+	}
+
+	summary := config.GetDisplaySummary()
+	assert.Contains(t, summary, "🐙 GitHub: Create in acme (🌐 Public)")
+	assert.Contains(t, summary, "👥 Teams: backend:push, sre:admin")
+}
+
+func TestProjectConfig_GetDisplaySummary_RepoSettings(t *testing.T) {
+	hasWiki := false
+	config := ProjectConfig{
+		Name:         "billing",
+		CreateGitHub: true,
+		RepoSettings: &RepositorySettings{MergeStrategies: []string{"squash"}, HasWiki: &hasWiki},
+	}
+
+	// 適用したリポジトリ設定も成功時のサマリーに表示する
+	summary := config.GetDisplaySummary()
+	assert.Contains(t, summary, "⚙️  Merge: squash only")
+	assert.Contains(t, summary, "⚙️  Wiki: Disabled")
+
+	// GitHub リポジトリを作らないなら表示しない
+	config.CreateGitHub = false
+	assert.NotContains(t, config.GetDisplaySummary(), "⚙️  Wiki: Disabled")
+}
+
+func TestProjectConfig_RepositoryOptions(t *testing.T) {
+	config := ProjectConfig{
+		Name:         "billing",
+		CreateGitHub: true,
+		Owner:        "acme",
+		Repository:   RepositoryOptions{Visibility: "internal", Topics: []string{"go"}, CustomProperties: map[string]string{"team": "platform"}},
+	}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, "internal", config.GetVisibility())
+
+	summary := config.GetDisplaySummary()
+	assert.Contains(t, summary, "🐙 GitHub: Create in acme (🏢 Internal)")
+	assert.Contains(t, summary, "🏷️  Topics: go")
+	assert.Contains(t, summary, "📋 Properties: team=platform")
+
+	// internal とカスタムプロパティには組織のオーナーが必要
+	config.Owner = ""
+	assert.Error(t, config.Validate())
+	config.Repository.Visibility = ""
+	assert.Error(t, config.Validate())
+
+	// GitHub リポジトリを作らないならオプションは指定できない
+	config = ProjectConfig{Name: "billing", Repository: RepositoryOptions{Topics: []string{"go"}}}
+	assert.Error(t, config.Validate())
+
+	// 未指定なら IsPrivate に従う
+	config = ProjectConfig{Name: "billing", IsPrivate: true}
+	assert.Equal(t, "private", config.GetVisibility())
+}
+
+func TestProjectConfig_GetTemplateData(t *testing.T) {
+	config := ProjectConfig{
+		Name:        "test-project",
+		Description: "テストプロジェクト",
+		IsPrivate:   true,
+		Variables: map[string]interface{}{
+			"use_ci": true,
+			"Name":   "shadowed",
+		},
+	}
+
+	data := config.GetTemplateData()
+
+	assert.Equal(t, "test-project", data["Name"]) // 組み込み値が優先
+	assert.Equal(t, "テストプロジェクト", data["Description"])
+	assert.Equal(t, true, data["Private"])
+	assert.Equal(t, true, data["use_ci"])
+	assert.Equal(t, "shadowed", data["Vars"].(map[string]interface{})["Name"])
+}
+
 func BenchmarkProjectConfig_Validate(b *testing.B) {
 	config := ProjectConfig{
 		Name:        "test-project",
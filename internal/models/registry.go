@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RegistryEntry is an approved template listed in a template registry
+type RegistryEntry struct {
+	// Template is a template reference: owner/repo, a git URL or a local path, optionally followed by //path
+	Template    string `yaml:"template"`
+	Name        string `yaml:"name"`
+	Category    string `yaml:"category"`
+	Description string `yaml:"description"`
+	Ref         string `yaml:"ref"`
+}
+
+// TemplateRegistry is a curated list of templates shared across a team
+type TemplateRegistry struct {
+	Templates []RegistryEntry `yaml:"templates"`
+}
+
+// ParseRegistry parses registry YAML or JSON data
+func ParseRegistry(data []byte) (*TemplateRegistry, error) {
+	var registry TemplateRegistry
+	if err := yaml.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse registry: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range registry.Templates {
+		if entry.Template == "" {
+			return nil, fmt.Errorf("registry entries require a template")
+		}
+		if seen[entry.Template] {
+			return nil, fmt.Errorf("template '%s' is listed more than once", entry.Template)
+		}
+		seen[entry.Template] = true
+	}
+
+	return &registry, nil
+}
+
+// Apply returns the template with the entry's display name, category, description and pinned ref.
+// Registry templates are recommended.
+func (e RegistryEntry) Apply(template Template) Template {
+	if e.Name != "" {
+		template.Title = e.Name
+	}
+	if e.Description != "" {
+		template.Description = e.Description
+	}
+	if e.Ref != "" {
+		template.Ref = e.Ref
+	}
+	template.Category = e.Category
+	template.Recommended = true
+	return template
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRegistry(t *testing.T) {
+	// YAML と JSON のどちらも読める
+	for _, data := range []string{
+		"templates:\n  - template: acme/go-service\n    name: Go service\n    category: Backend\n    ref: v1.4.0\n",
+		`{"templates": [{"template": "acme/go-service", "name": "Go service", "category": "Backend", "ref": "v1.4.0"}]}`,
+	} {
+		registry, err := ParseRegistry([]byte(data))
+		require.NoError(t, err)
+		assert.Equal(t, []RegistryEntry{
+			{Template: "acme/go-service", Name: "Go service", Category: "Backend", Ref: "v1.4.0"},
+		}, registry.Templates)
+	}
+
+	_, err := ParseRegistry([]byte("templates:\n  - name: Go service\n"))
+	assert.ErrorContains(t, err, "require a template")
+
+	_, err = ParseRegistry([]byte("templates:\n  - template: acme/web\n  - template: acme/web\n"))
+	assert.ErrorContains(t, err, "listed more than once")
+}
+
+func TestRegistryEntry_Apply(t *testing.T) {
+	template := Template{Name: "go-service", FullName: "acme/go-service", Description: "Original", Stars: 3}
+
+	// 表示名・カテゴリ・説明・ref を上書きし、推奨にする
+	applied := RegistryEntry{Name: "Go service", Category: "Backend", Description: "Approved", Ref: "v1.4.0"}.Apply(template)
+	assert.Equal(t, "Go service", applied.GetTitle())
+	assert.Equal(t, "Backend", applied.Category)
+	assert.Equal(t, "Approved", applied.Description)
+	assert.Equal(t, "v1.4.0", applied.Ref)
+	assert.True(t, applied.Recommended)
+	assert.Equal(t, 3, applied.Stars)
+
+	// 省略した項目は元の値を残す
+	applied = RegistryEntry{Template: "acme/go-service"}.Apply(template)
+	assert.Equal(t, "go-service", applied.GetTitle())
+	assert.Equal(t, "Original", applied.Description)
+	assert.Empty(t, applied.Ref)
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RulesetEnforcements are the enforcement levels of a ruleset
+var RulesetEnforcements = []string{"active", "evaluate", "disabled"}
+
+// Ruleset is a repository ruleset applied to branches of the new repository
+type Ruleset struct {
+	Name string `yaml:"name" json:"name"`
+	// Enforcement is active (default), evaluate or disabled
+	Enforcement string `yaml:"enforcement,omitempty" json:"enforcement,omitempty"`
+	// Branches are branch names or fnmatch patterns; ~DEFAULT_BRANCH and ~ALL are also accepted
+	Branches                []string `yaml:"branches" json:"branches"`
+	Exclude                 []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	RequiredReviews         int      `yaml:"required_reviews,omitempty" json:"required_reviews,omitempty"`
+	DismissStaleReviews     bool     `yaml:"dismiss_stale_reviews,omitempty" json:"dismiss_stale_reviews,omitempty"`
+	RequireCodeOwnerReviews bool     `yaml:"require_code_owner_reviews,omitempty" json:"require_code_owner_reviews,omitempty"`
+	RequiredStatusChecks    []string `yaml:"required_status_checks,omitempty" json:"required_status_checks,omitempty"`
+	StrictStatusChecks      bool     `yaml:"strict_status_checks,omitempty" json:"strict_status_checks,omitempty"`
+	LinearHistory           bool     `yaml:"linear_history,omitempty" json:"linear_history,omitempty"`
+	SignedCommits           bool     `yaml:"signed_commits,omitempty" json:"signed_commits,omitempty"`
+}
+
+// Validate checks the validity of the ruleset
+func (r *Ruleset) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("rulesets require a name")
+	}
+	if r.Enforcement != "" && !containsString(RulesetEnforcements, r.Enforcement) {
+		return fmt.Errorf("invalid enforcement '%s' for ruleset '%s': use one of %s", r.Enforcement, r.Name, strings.Join(RulesetEnforcements, ", "))
+	}
+	if len(r.Branches) == 0 {
+		return fmt.Errorf("ruleset '%s' requires branches", r.Name)
+	}
+	if r.RequiredReviews < 0 || r.RequiredReviews > 10 {
+		return fmt.Errorf("required reviews for ruleset '%s' must be between 0 and 10", r.Name)
+	}
+	if !r.RequiresPullRequest() && len(r.RequiredStatusChecks) == 0 && !r.LinearHistory && !r.SignedCommits {
+		return fmt.Errorf("ruleset '%s' has no rules", r.Name)
+	}
+	return nil
+}
+
+// GetEnforcement returns the enforcement level, active when unset
+func (r *Ruleset) GetEnforcement() string {
+	if r.Enforcement == "" {
+		return "active"
+	}
+	return r.Enforcement
+}
+
+// RequiresPullRequest reports whether changes must go through a pull request
+func (r *Ruleset) RequiresPullRequest() bool {
+	return r.RequiredReviews > 0 || r.DismissStaleReviews || r.RequireCodeOwnerReviews
+}
+
+// GetDisplaySummary returns the ruleset as one line for display
+func (r *Ruleset) GetDisplaySummary() string {
+	var rules []string
+	if r.RequiresPullRequest() {
+		rules = append(rules, fmt.Sprintf("%d review(s)", r.RequiredReviews))
+	}
+	if len(r.RequiredStatusChecks) > 0 {
+		rules = append(rules, "checks "+strings.Join(r.RequiredStatusChecks, ", "))
+	}
+	if r.LinearHistory {
+		rules = append(rules, "linear history")
+	}
+	if r.SignedCommits {
+		rules = append(rules, "signed commits")
+	}
+
+	line := fmt.Sprintf("Ruleset %s on %s: %s", r.Name, strings.Join(r.Branches, ", "), strings.Join(rules, "; "))
+	if r.GetEnforcement() != "active" {
+		line += fmt.Sprintf(" (%s)", r.GetEnforcement())
+	}
+	return line
+}
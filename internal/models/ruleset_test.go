@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleset_Validate(t *testing.T) {
+	valid := Ruleset{Name: "protect-main", Branches: []string{"main"}, LinearHistory: true}
+	assert.NoError(t, valid.Validate())
+	assert.Equal(t, "active", valid.GetEnforcement())
+
+	invalid := []Ruleset{
+		{Branches: []string{"main"}, LinearHistory: true},
+		{Name: "protect-main", LinearHistory: true},
+		{Name: "protect-main", Branches: []string{"main"}, Enforcement: "strict", LinearHistory: true},
+		{Name: "protect-main", Branches: []string{"main"}, RequiredReviews: 11},
+		{Name: "protect-main", Branches: []string{"main"}}, // ルールなし
+	}
+	for _, ruleset := range invalid {
+		assert.Error(t, ruleset.Validate(), ruleset)
+	}
+
+	// 同名のルールセットは重複エラー
+	settings := RepositorySettings{Rulesets: []Ruleset{valid, valid}}
+	assert.ErrorContains(t, settings.Validate(), "declared more than once")
+}
+
+func TestRuleset_GetDisplaySummary(t *testing.T) {
+	ruleset := Ruleset{
+		Name:                 "protect-main",
+		Enforcement:          "evaluate",
+		Branches:             []string{"main", "release/*"},
+		RequiredReviews:      1,
+		RequiredStatusChecks: []string{"test"},
+		LinearHistory:        true,
+		SignedCommits:        true,
+	}
+
+	assert.Equal(t,
+		"Ruleset protect-main on main, release/*: 1 review(s); checks test; linear history; signed commits (evaluate)",
+		ruleset.GetDisplaySummary())
+}
@@ -0,0 +1,219 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeStrategies are the pull request merge methods a repository can allow
+var MergeStrategies = []string{"merge", "squash", "rebase"}
+
+// RepositorySettings are settings applied to a new GitHub repository after it is created.
+// Unset fields keep GitHub's defaults.
+type RepositorySettings struct {
+	// MergeStrategies lists the allowed merge methods; the others are disabled
+	MergeStrategies     []string           `yaml:"merge_strategies,omitempty" json:"merge_strategies,omitempty"`
+	DeleteBranchOnMerge *bool              `yaml:"delete_branch_on_merge,omitempty" json:"delete_branch_on_merge,omitempty"`
+	HasWiki             *bool              `yaml:"has_wiki,omitempty" json:"has_wiki,omitempty"`
+	BranchProtection    []BranchProtection `yaml:"branch_protection,omitempty" json:"branch_protection,omitempty"`
+	Rulesets            []Ruleset          `yaml:"rulesets,omitempty" json:"rulesets,omitempty"`
+	Labels              []Label            `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// RemoveDefaultLabels deletes the labels GitHub creates that are not among Labels
+	RemoveDefaultLabels bool        `yaml:"remove_default_labels,omitempty" json:"remove_default_labels,omitempty"`
+	Milestones          []Milestone `yaml:"milestones,omitempty" json:"milestones,omitempty"`
+	Issues              []Issue     `yaml:"issues,omitempty" json:"issues,omitempty"`
+}
+
+// BranchProtection protects a branch of the new repository
+type BranchProtection struct {
+	Branch                  string   `yaml:"branch" json:"branch"`
+	RequiredReviews         int      `yaml:"required_reviews,omitempty" json:"required_reviews,omitempty"`
+	DismissStaleReviews     bool     `yaml:"dismiss_stale_reviews,omitempty" json:"dismiss_stale_reviews,omitempty"`
+	RequireCodeOwnerReviews bool     `yaml:"require_code_owner_reviews,omitempty" json:"require_code_owner_reviews,omitempty"`
+	RequiredStatusChecks    []string `yaml:"required_status_checks,omitempty" json:"required_status_checks,omitempty"`
+	// StrictStatusChecks requires branches to be up to date with the protected branch before merging
+	StrictStatusChecks bool `yaml:"strict_status_checks,omitempty" json:"strict_status_checks,omitempty"`
+	EnforceAdmins      bool `yaml:"enforce_admins,omitempty" json:"enforce_admins,omitempty"`
+}
+
+// Validate checks the validity of the settings
+func (s *RepositorySettings) Validate() error {
+	for _, strategy := range s.MergeStrategies {
+		if !containsString(MergeStrategies, strategy) {
+			return fmt.Errorf("invalid merge strategy '%s': use one of %s", strategy, strings.Join(MergeStrategies, ", "))
+		}
+	}
+
+	branches := make(map[string]bool)
+	for _, protection := range s.BranchProtection {
+		if protection.Branch == "" {
+			return fmt.Errorf("branch protection requires a branch")
+		}
+		if branches[protection.Branch] {
+			return fmt.Errorf("branch '%s' is protected more than once", protection.Branch)
+		}
+		branches[protection.Branch] = true
+
+		if protection.RequiredReviews < 0 || protection.RequiredReviews > 6 {
+			return fmt.Errorf("required reviews for branch '%s' must be between 0 and 6", protection.Branch)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, ruleset := range s.Rulesets {
+		if err := ruleset.Validate(); err != nil {
+			return err
+		}
+		if names[ruleset.Name] {
+			return fmt.Errorf("ruleset '%s' is declared more than once", ruleset.Name)
+		}
+		names[ruleset.Name] = true
+	}
+
+	return s.validateBootstrap()
+}
+
+// IsEmpty reports whether the settings change nothing
+func (s *RepositorySettings) IsEmpty() bool {
+	return s == nil || (len(s.MergeStrategies) == 0 && s.DeleteBranchOnMerge == nil && s.HasWiki == nil &&
+		len(s.BranchProtection) == 0 && len(s.Rulesets) == 0 && !s.HasBootstrap())
+}
+
+// MergeRepositorySettings combines settings in increasing precedence: fields set by a later settings
+// block replace earlier ones, branch protection is replaced branch by branch and rulesets by name.
+// Labels, milestones and issues of a later block replace the earlier ones as a whole.
+func MergeRepositorySettings(settings ...*RepositorySettings) *RepositorySettings {
+	merged := &RepositorySettings{}
+	for _, s := range settings {
+		if s == nil {
+			continue
+		}
+		if len(s.MergeStrategies) > 0 {
+			merged.MergeStrategies = s.MergeStrategies
+		}
+		if s.DeleteBranchOnMerge != nil {
+			merged.DeleteBranchOnMerge = s.DeleteBranchOnMerge
+		}
+		if s.HasWiki != nil {
+			merged.HasWiki = s.HasWiki
+		}
+		for _, protection := range s.BranchProtection {
+			merged.setBranchProtection(protection)
+		}
+		for _, ruleset := range s.Rulesets {
+			merged.setRuleset(ruleset)
+		}
+		if len(s.Labels) > 0 {
+			merged.Labels = s.Labels
+		}
+		if s.RemoveDefaultLabels {
+			merged.RemoveDefaultLabels = true
+		}
+		if len(s.Milestones) > 0 {
+			merged.Milestones = s.Milestones
+		}
+		if len(s.Issues) > 0 {
+			merged.Issues = s.Issues
+		}
+	}
+
+	if merged.IsEmpty() {
+		return nil
+	}
+	return merged
+}
+
+// setBranchProtection adds the protection or replaces the one of the same branch
+func (s *RepositorySettings) setBranchProtection(protection BranchProtection) {
+	for i := range s.BranchProtection {
+		if s.BranchProtection[i].Branch == protection.Branch {
+			s.BranchProtection[i] = protection
+			return
+		}
+	}
+	s.BranchProtection = append(s.BranchProtection, protection)
+}
+
+// setRuleset adds the ruleset or replaces the one of the same name
+func (s *RepositorySettings) setRuleset(ruleset Ruleset) {
+	for i := range s.Rulesets {
+		if s.Rulesets[i].Name == ruleset.Name {
+			s.Rulesets[i] = ruleset
+			return
+		}
+	}
+	s.Rulesets = append(s.Rulesets, ruleset)
+}
+
+// GetDisplaySummary returns one line per setting for display
+func (s *RepositorySettings) GetDisplaySummary() []string {
+	if s.IsEmpty() {
+		return nil
+	}
+
+	var lines []string
+	if len(s.MergeStrategies) > 0 {
+		lines = append(lines, fmt.Sprintf("Merge: %s only", strings.Join(s.MergeStrategies, ", ")))
+	}
+	if s.DeleteBranchOnMerge != nil {
+		lines = append(lines, fmt.Sprintf("Delete branch on merge: %s", enabledLabel(*s.DeleteBranchOnMerge)))
+	}
+	if s.HasWiki != nil {
+		lines = append(lines, fmt.Sprintf("Wiki: %s", enabledLabel(*s.HasWiki)))
+	}
+	for _, protection := range s.BranchProtection {
+		rules := []string{fmt.Sprintf("%d review(s)", protection.RequiredReviews)}
+		if len(protection.RequiredStatusChecks) > 0 {
+			rules = append(rules, "checks "+strings.Join(protection.RequiredStatusChecks, ", "))
+		}
+		if protection.EnforceAdmins {
+			rules = append(rules, "admins included")
+		}
+		lines = append(lines, fmt.Sprintf("Protect %s: %s", protection.Branch, strings.Join(rules, "; ")))
+	}
+	for _, ruleset := range s.Rulesets {
+		lines = append(lines, ruleset.GetDisplaySummary())
+	}
+	if len(s.Labels) > 0 || s.RemoveDefaultLabels {
+		names := make([]string, len(s.Labels))
+		for i, label := range s.Labels {
+			names[i] = label.Name
+		}
+		line := "Labels: " + strings.Join(names, ", ")
+		if s.RemoveDefaultLabels && len(names) == 0 {
+			line = "Labels: default labels removed"
+		} else if s.RemoveDefaultLabels {
+			line += " (default labels removed)"
+		}
+		lines = append(lines, line)
+	}
+	if len(s.Milestones) > 0 {
+		titles := make([]string, len(s.Milestones))
+		for i, milestone := range s.Milestones {
+			titles[i] = milestone.Title
+		}
+		lines = append(lines, "Milestones: "+strings.Join(titles, ", "))
+	}
+	if len(s.Issues) > 0 {
+		lines = append(lines, fmt.Sprintf("Issues: %d to open", len(s.Issues)))
+	}
+	return lines
+}
+
+// enabledLabel formats a switch for display
+func enabledLabel(enabled bool) string {
+	if enabled {
+		return "Enabled"
+	}
+	return "Disabled"
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRepositorySettings_Validate(t *testing.T) {
+	valid := RepositorySettings{
+		MergeStrategies:  []string{"squash"},
+		BranchProtection: []BranchProtection{{Branch: "main", RequiredReviews: 2}},
+	}
+	assert.NoError(t, valid.Validate())
+
+	invalid := []RepositorySettings{
+		{MergeStrategies: []string{"fast-forward"}},
+		{BranchProtection: []BranchProtection{{RequiredReviews: 1}}},
+		{BranchProtection: []BranchProtection{{Branch: "main"}, {Branch: "main"}}},
+		{BranchProtection: []BranchProtection{{Branch: "main", RequiredReviews: 7}}},
+	}
+	for _, settings := range invalid {
+		assert.Error(t, settings.Validate(), settings)
+	}
+}
+
+func TestMergeRepositorySettings(t *testing.T) {
+	var template, user RepositorySettings
+	require.NoError(t, yaml.Unmarshal([]byte(`
+merge_strategies: [merge, squash]
+has_wiki: true
+branch_protection:
+  - branch: main
+    required_reviews: 1
+  - branch: release
+    enforce_admins: true
+`), &template))
+	require.NoError(t, yaml.Unmarshal([]byte(`
+merge_strategies: [squash]
+delete_branch_on_merge: true
+has_wiki: false
+branch_protection:
+  - branch: main
+    required_reviews: 2
+    required_status_checks: [test]
+`), &user))
+
+	// 後の設定が項目ごと・ブランチごとに優先される
+	merged := MergeRepositorySettings(&template, &user)
+	assert.Equal(t, []string{"squash"}, merged.MergeStrategies)
+	assert.True(t, *merged.DeleteBranchOnMerge)
+	assert.False(t, *merged.HasWiki)
+	assert.Equal(t, []BranchProtection{
+		{Branch: "main", RequiredReviews: 2, RequiredStatusChecks: []string{"test"}},
+		{Branch: "release", EnforceAdmins: true},
+	}, merged.BranchProtection)
+
+	assert.Equal(t, []string{
+		"Merge: squash only",
+		"Delete branch on merge: Enabled",
+		"Wiki: Disabled",
+		"Protect main: 2 review(s); checks test",
+		"Protect release: 0 review(s); admins included",
+	}, merged.GetDisplaySummary())
+
+	// ルールセットは名前ごとに置き換わる
+	merged = MergeRepositorySettings(
+		&RepositorySettings{Rulesets: []Ruleset{{Name: "main", Branches: []string{"main"}, LinearHistory: true}}},
+		&RepositorySettings{Rulesets: []Ruleset{{Name: "main", Branches: []string{"main"}, SignedCommits: true}}},
+	)
+	assert.Equal(t, []Ruleset{{Name: "main", Branches: []string{"main"}, SignedCommits: true}}, merged.Rulesets)
+
+	// 何も設定がなければ nil
+	assert.Nil(t, MergeRepositorySettings(nil, &RepositorySettings{}))
+}
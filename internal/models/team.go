@@ -0,0 +1,42 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TeamPermissions are the repository permissions a team can be granted, from least to most access
+var TeamPermissions = []string{"pull", "triage", "push", "maintain", "admin"}
+
+// defaultTeamPermission is granted when a team is given without a permission
+const defaultTeamPermission = "push"
+
+// TeamGrant gives an organization team access to the new repository
+type TeamGrant struct {
+	// Team is the team's slug in the organization owning the repository
+	Team       string `json:"team"`
+	Permission string `json:"permission"`
+}
+
+// ParseTeamGrant parses "team" or "team:permission"
+func ParseTeamGrant(value string) (TeamGrant, error) {
+	team, permission, hasPermission := strings.Cut(strings.TrimSpace(value), ":")
+	if team == "" || strings.Contains(team, "/") {
+		return TeamGrant{}, fmt.Errorf("invalid team '%s': use team or team:permission", value)
+	}
+
+	if !hasPermission {
+		permission = defaultTeamPermission
+	}
+	for _, allowed := range TeamPermissions {
+		if permission == allowed {
+			return TeamGrant{Team: team, Permission: permission}, nil
+		}
+	}
+	return TeamGrant{}, fmt.Errorf("invalid permission '%s' for team '%s': use one of %s", permission, team, strings.Join(TeamPermissions, ", "))
+}
+
+// String returns the grant as team:permission
+func (g TeamGrant) String() string {
+	return g.Team + ":" + g.Permission
+}
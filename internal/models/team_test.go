@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTeamGrant(t *testing.T) {
+	grant, err := ParseTeamGrant("backend:maintain")
+	require.NoError(t, err)
+	assert.Equal(t, TeamGrant{Team: "backend", Permission: "maintain"}, grant)
+	assert.Equal(t, "backend:maintain", grant.String())
+
+	// 権限を省略すると push になる
+	grant, err = ParseTeamGrant("backend")
+	require.NoError(t, err)
+	assert.Equal(t, "push", grant.Permission)
+
+	for _, value := range []string{"", ":push", "acme/backend:push", "backend:write"} {
+		_, err := ParseTeamGrant(value)
+		assert.Error(t, err, value)
+	}
+}
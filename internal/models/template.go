@@ -2,26 +2,49 @@ package models
 
 import (
 	"fmt"
+	"path"
+	"strings"
 	"time"
 )
 
 // Template represents GitHub template repository information
 type Template struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	FullName    string    `json:"full_name"`
-	Owner       string    `json:"owner"`
-	Description string    `json:"description"`
-	Stars       int       `json:"stars"`
-	Forks       int       `json:"forks"`
-	Language    string    `json:"language"`
-	Topics      []string  `json:"topics"`
-	IsTemplate  bool      `json:"is_template"`
-	Private     bool      `json:"private"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	CloneURL    string    `json:"clone_url"`
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	FullName    string         `json:"full_name"`
+	Owner       string         `json:"owner"`
+	Description string         `json:"description"`
+	Stars       int            `json:"stars"`
+	Forks       int            `json:"forks"`
+	Language    string         `json:"language"`
+	Topics      []string       `json:"topics"`
+	IsTemplate  bool           `json:"is_template"`
+	Private     bool           `json:"private"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	CloneURL    string         `json:"clone_url"`
+	Source      TemplateSource `json:"source,omitempty"`
+	// Ref is the branch, tag or commit to check out (empty for the default branch)
+	Ref string `json:"ref,omitempty"`
+	// Path is the subdirectory of the repository that holds the template (empty for the root)
+	Path string `json:"path,omitempty"`
+	// Title, Category and Recommended are set by template registries
+	Title       string `json:"title,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Recommended bool   `json:"recommended,omitempty"`
 }
 
+// TemplateSource is where a template is read from
+type TemplateSource string
+
+const (
+	// SourceGitHub templates are GitHub repositories (the default)
+	SourceGitHub TemplateSource = "github"
+	// SourceLocal templates are directories on this machine
+	SourceLocal TemplateSource = "local"
+	// SourceGit templates are cloned from a git URL on any host
+	SourceGit TemplateSource = "git"
+)
+
 // GetDisplayName returns the display name of template repository
 func (t Template) GetDisplayName() string {
 	result := t.Name
@@ -39,6 +62,104 @@ func (t Template) GetDisplayName() string {
 	return result
 }
 
+// GetTitle returns the display name a registry gave the template, or its name
+func (t Template) GetTitle() string {
+	if t.Title != "" {
+		return t.Title
+	}
+	return t.Name
+}
+
+// GetSource returns where the template is read from
+func (t Template) GetSource() TemplateSource {
+	if t.Source == "" {
+		return SourceGitHub
+	}
+	return t.Source
+}
+
+// IsLocal returns whether the template is a local directory
+func (t Template) IsLocal() bool {
+	return t.GetSource() == SourceLocal
+}
+
+// GetReference returns what identifies the template to the user
+// (owner/repo, or the path of a local template, followed by //subdir for a subdirectory template)
+func (t Template) GetReference() string {
+	reference := t.FullName
+	if reference == "" {
+		reference = t.CloneURL
+	}
+	if t.Path != "" {
+		reference += TemplatePathSeparator + t.Path
+	}
+	return reference
+}
+
+// SubTemplates returns the templates a collection manifest declares in this repository.
+// Each inherits the repository details and is told apart by its Path.
+func (t Template) SubTemplates(entries []ManifestTemplate) []Template {
+	templates := make([]Template, 0, len(entries))
+	for _, entry := range entries {
+		template := t
+		template.Path = path.Join(t.Path, entry.Path)
+		template.Name = entry.Name
+		if template.Name == "" {
+			template.Name = path.Base(entry.Path)
+		}
+		if entry.Description != "" {
+			template.Description = entry.Description
+		}
+		template.Topics = append(append([]string{}, t.Topics...), entry.Topics...)
+		templates = append(templates, template)
+	}
+	return templates
+}
+
+// WithPath returns the template kept in subdir of this repository (the template itself for an empty subdir)
+func (t Template) WithPath(subdir string) Template {
+	if subdir == "" {
+		return t
+	}
+	t.Path = subdir
+	t.Name = path.Base(subdir)
+	return t
+}
+
+// TemplatePathSeparator separates a repository from a template subdirectory (owner/repo//path/to/template)
+const TemplatePathSeparator = "//"
+
+// SplitTemplatePath splits "owner/repo//path/to/template" (or a git URL followed by //path) into
+// the repository and the subdirectory. The subdirectory is empty when the reference has none.
+func SplitTemplatePath(reference string) (string, string, error) {
+	// Skip the "//" of a URL scheme
+	start := 0
+	if index := strings.Index(reference, "://"); index != -1 {
+		start = index + len("://")
+	}
+
+	index := strings.Index(reference[start:], TemplatePathSeparator)
+	if index == -1 {
+		return reference, "", nil
+	}
+	index += start
+
+	subdir, err := CleanTemplatePath(reference[index+len(TemplatePathSeparator):])
+	if err != nil {
+		return "", "", err
+	}
+	return reference[:index], subdir, nil
+}
+
+// CleanTemplatePath normalizes a template subdirectory, which must stay inside the repository
+func CleanTemplatePath(subdir string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(subdir, "\\", "/"))
+	if subdir == "" || cleaned == "." || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", NewValidationError(fmt.Sprintf("Invalid template path '%s': it must be a subdirectory of the repository", subdir))
+	}
+	return cleaned, nil
+}
+
 // GetShortDescription returns shortened description
 func (t Template) GetShortDescription() string {
 	if t.Description == "" {
@@ -59,3 +180,98 @@ func (t Template) GetRepoURL() string {
 func (t Template) GetIsPublic() bool {
 	return !t.Private
 }
+
+// HasTopic returns whether the template is tagged with the topic (case-insensitive)
+func (t Template) HasTopic(topic string) bool {
+	for _, candidate := range t.Topics {
+		if strings.EqualFold(candidate, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// TemplateFilter narrows templates by topic and language.
+// A template matches when it has every topic and the language (case-insensitive).
+type TemplateFilter struct {
+	Topics   []string
+	Language string
+}
+
+// IsEmpty returns whether the filter keeps every template
+func (f TemplateFilter) IsEmpty() bool {
+	return len(f.Topics) == 0 && f.Language == ""
+}
+
+// Matches returns whether the template passes the filter
+func (f TemplateFilter) Matches(template Template) bool {
+	if f.Language != "" && !strings.EqualFold(template.Language, f.Language) {
+		return false
+	}
+	for _, topic := range f.Topics {
+		if !template.HasTopic(topic) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the templates that pass the filter
+func (f TemplateFilter) Apply(templates []Template) []Template {
+	var result []Template
+	for _, template := range templates {
+		if f.Matches(template) {
+			result = append(result, template)
+		}
+	}
+	return result
+}
+
+// String describes the filter for messages
+func (f TemplateFilter) String() string {
+	var parts []string
+	if len(f.Topics) > 0 {
+		parts = append(parts, "topic "+strings.Join(f.Topics, ", "))
+	}
+	if f.Language != "" {
+		parts = append(parts, "language "+f.Language)
+	}
+	return strings.Join(parts, " and ")
+}
+
+// TemplateSortOrder is the order templates are listed in
+type TemplateSortOrder string
+
+const (
+	SortByStars   TemplateSortOrder = "stars"
+	SortByUpdated TemplateSortOrder = "updated"
+	SortByName    TemplateSortOrder = "name"
+)
+
+// TemplateSortOrders lists the supported sort orders
+var TemplateSortOrders = []TemplateSortOrder{SortByStars, SortByUpdated, SortByName}
+
+// ParseTemplateSortOrder parses a sort order name (empty means stars)
+func ParseTemplateSortOrder(value string) (TemplateSortOrder, error) {
+	if value == "" {
+		return SortByStars, nil
+	}
+	for _, order := range TemplateSortOrders {
+		if string(order) == value {
+			return order, nil
+		}
+	}
+	return "", NewValidationError(fmt.Sprintf("unknown sort order '%s' (use stars, updated or name)", value))
+}
+
+// GetLabel returns the label shown in the sort order prompt
+func (o TemplateSortOrder) GetLabel() string {
+	switch o {
+	case SortByUpdated:
+		return "Recently updated"
+	case SortByName:
+		return "Name"
+	default:
+		return "Stars"
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTemplate_GetDisplayName(t *testing.T) {
@@ -124,3 +125,107 @@ func TestTemlate_IsPublic(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTemplateSortOrder(t *testing.T) {
+	order, err := ParseTemplateSortOrder("")
+	assert.NoError(t, err)
+	assert.Equal(t, SortByStars, order)
+
+	order, err = ParseTemplateSortOrder("updated")
+	assert.NoError(t, err)
+	assert.Equal(t, SortByUpdated, order)
+	assert.Equal(t, "Recently updated", order.GetLabel())
+
+	_, err = ParseTemplateSortOrder("forks")
+	assert.Error(t, err)
+}
+
+func TestTemplateFilter(t *testing.T) {
+	templates := []Template{
+		{Name: "api", Language: "Go", Topics: []string{"backend", "internal-only"}},
+		{Name: "web", Language: "TypeScript", Topics: []string{"frontend"}},
+		{Name: "worker", Language: "go", Topics: []string{"Backend"}},
+	}
+	names := func(templates []Template) []string {
+		var result []string
+		for _, template := range templates {
+			result = append(result, template.Name)
+		}
+		return result
+	}
+
+	// 空のフィルターはすべて残す
+	assert.True(t, TemplateFilter{}.IsEmpty())
+	assert.Len(t, TemplateFilter{}.Apply(templates), 3)
+
+	// トピックと言語は大文字小文字を区別しない
+	assert.Equal(t, []string{"api", "worker"}, names(TemplateFilter{Topics: []string{"backend"}}.Apply(templates)))
+	assert.Equal(t, []string{"api", "worker"}, names(TemplateFilter{Language: "GO"}.Apply(templates)))
+
+	// 複数のトピックはすべて一致する必要がある
+	filter := TemplateFilter{Topics: []string{"backend", "internal-only"}, Language: "Go"}
+	assert.Equal(t, []string{"api"}, names(filter.Apply(templates)))
+	assert.Equal(t, "topic backend, internal-only and language Go", filter.String())
+}
+
+func TestSplitTemplatePath(t *testing.T) {
+	tests := []struct {
+		reference  string
+		repository string
+		subdir     string
+	}{
+		{"acme/templates", "acme/templates", ""},
+		{"acme/templates//services/go", "acme/templates", "services/go"},
+		{"acme/templates//services/go/", "acme/templates", "services/go"},
+		{"https://example.com/acme/templates.git//web", "https://example.com/acme/templates.git", "web"},
+		{"git@example.com:acme/templates.git//web", "git@example.com:acme/templates.git", "web"},
+		{"https://example.com/acme/templates.git", "https://example.com/acme/templates.git", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			repository, subdir, err := SplitTemplatePath(tt.reference)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.repository, repository)
+			assert.Equal(t, tt.subdir, subdir)
+		})
+	}
+
+	// リポジトリの外を指すパスは受け付けない
+	for _, reference := range []string{"acme/templates//", "acme/templates//../secrets", "acme/templates///etc"} {
+		_, _, err := SplitTemplatePath(reference)
+		assert.Error(t, err, reference)
+	}
+}
+
+func TestTemplate_SubTemplates(t *testing.T) {
+	collection := Template{
+		Name:     "templates",
+		FullName: "acme/templates",
+		Stars:    12,
+		Topics:   []string{"platform"},
+	}
+
+	templates := collection.SubTemplates([]ManifestTemplate{
+		{Name: "go-service", Path: "services/go", Description: "Go microservice", Topics: []string{"go"}},
+		{Path: "web"},
+	})
+	require.Len(t, templates, 2)
+
+	// リポジトリの情報を引き継ぎ、パスで区別する
+	assert.Equal(t, "go-service", templates[0].Name)
+	assert.Equal(t, "Go microservice", templates[0].Description)
+	assert.Equal(t, 12, templates[0].Stars)
+	assert.Equal(t, []string{"platform", "go"}, templates[0].Topics)
+	assert.Equal(t, "acme/templates//services/go", templates[0].GetReference())
+
+	// 名前を省略するとディレクトリ名になる
+	assert.Equal(t, "web", templates[1].Name)
+	assert.Equal(t, []string{"platform"}, templates[1].Topics)
+
+	// WithPath は単一のサブディレクトリを指す
+	template := collection.WithPath("tools/cli")
+	assert.Equal(t, "cli", template.Name)
+	assert.Equal(t, "acme/templates//tools/cli", template.GetReference())
+	assert.Equal(t, collection, collection.WithPath(""))
+}
@@ -2,43 +2,38 @@ package utils
 
 import (
 	"context"
-	"fmt"
-	"os"
 	"os/exec"
-	"strings"
 
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
 )
 
-// GitService provides Git operations
+// GitService provides Git operations on a working directory
 type GitService struct {
 	workingDir string
+	backend    GitBackend
 }
 
-// NewGitService creates a new Git service
+// NewGitService creates a new Git service using the default backend
 func NewGitService(workingDir string) *GitService {
-	return &GitService{workingDir: workingDir}
+	return NewGitServiceWithBackend(workingDir, DefaultGitBackend())
+}
+
+// NewGitServiceWithBackend creates a new Git service using the given backend
+func NewGitServiceWithBackend(workingDir string, backend GitBackend) *GitService {
+	return &GitService{workingDir: workingDir, backend: backend}
 }
 
 // InitializeRepository initializes Git repository
 func (gs *GitService) InitializeRepository(ctx context.Context) error {
-	// git init
-	if err := gs.runGitCommand(ctx, "init"); err != nil {
+	if err := gs.backend.Init(ctx, gs.workingDir); err != nil {
 		return models.NewProjectError("Failed to initialize Git", err)
 	}
-
-	// Set default branch to main
-	if err := gs.runGitCommand(ctx, "branch", "-M", "main"); err != nil {
-		// Skip for older Git versions
-		fmt.Println("Warning: Skipped default branch configuration")
-	}
-
 	return nil
 }
 
 // AddAllFiles adds all files to staging area
 func (gs *GitService) AddAllFiles(ctx context.Context) error {
-	return gs.runGitCommand(ctx, "add", ".")
+	return gs.backend.AddAll(ctx, gs.workingDir)
 }
 
 // CreateInitialCommit creates initial commit
@@ -47,35 +42,32 @@ func (gs *GitService) CreateInitialCommit(ctx context.Context, message string) e
 		message = "Initial commit"
 	}
 
-	return gs.runGitCommand(ctx, "commit", "-m", message)
+	return gs.backend.Commit(ctx, gs.workingDir, message)
 }
 
 // AddRemote adds remote repository
 func (gs *GitService) AddRemote(ctx context.Context, name, url string) error {
-	return gs.runGitCommand(ctx, "remote", "add", name, url)
+	return gs.backend.AddRemote(ctx, gs.workingDir, name, url)
 }
 
 // PushToRemote pushes to remote repository
 func (gs *GitService) PushToRemote(ctx context.Context, remote, branch string) error {
-	return gs.runGitCommand(ctx, "push", "-u", remote, branch)
+	return gs.backend.Push(ctx, gs.workingDir, remote, branch)
 }
 
 // SetUpstreamBranch sets upstream branch
 func (gs *GitService) SetUpstreamBranch(ctx context.Context, remote, branch string) error {
-	return gs.runGitCommand(ctx, "branch", "--set-upstream-to", fmt.Sprintf("%s/%s", remote, branch))
+	return gs.backend.SetUpstream(ctx, gs.workingDir, remote, branch)
 }
 
 // GetCurrentBranch gets current branch name
 func (gs *GitService) GetCurrentBranch(ctx context.Context) (string, error) {
-	cmd := exec.CommandContext(ctx, "git", "branch", "--show-current")
-	cmd.Dir = gs.workingDir
-
-	output, err := cmd.Output()
+	branch, err := gs.backend.CurrentBranch(ctx, gs.workingDir)
 	if err != nil {
 		return "", models.NewProjectError("Failed to get current branch", err)
 	}
 
-	return strings.TrimSpace(string(output)), nil
+	return branch, nil
 }
 
 // CheckGitInstallation checks if Git is installed
@@ -92,45 +84,18 @@ func (gs *GitService) CheckGitInstallation() error {
 
 // ConfigureUserInfo configures user information (if needed)
 func (gs *GitService) ConfigureUserInfo(ctx context.Context, name, email string) error {
-	// Check global configuration
-	if err := gs.checkGitConfig(ctx, "user.name"); err != nil {
-		if name != "" {
-			if err := gs.runGitCommand(ctx, "config", "user.name", name); err != nil {
-				return models.NewProjectError("Failed to configure Git username", err)
-			}
-		}
-	}
-
-	if err := gs.checkGitConfig(ctx, "user.email"); err != nil {
-		if email != "" {
-			if err := gs.runGitCommand(ctx, "config", "user.email", email); err != nil {
-				return models.NewProjectError("Failed to configure Git email address", err)
-			}
-		}
+	if err := gs.backend.ConfigureUser(ctx, gs.workingDir, name, email); err != nil {
+		return models.NewProjectError("Failed to configure Git user information", err)
 	}
-
 	return nil
 }
 
-// runGitCommand executes Git command
-func (gs *GitService) runGitCommand(ctx context.Context, args ...string) error {
-	cmd := exec.CommandContext(ctx, "git", args...)
-	cmd.Dir = gs.workingDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	return cmd.Run()
-}
-
-// checkGitConfig checks Git configuration
-func (gs *GitService) checkGitConfig(ctx context.Context, key string) error {
-	cmd := exec.CommandContext(ctx, "git", "config", key)
-	cmd.Dir = gs.workingDir
-
-	output, err := cmd.Output()
-	if err != nil || strings.TrimSpace(string(output)) == "" {
-		return fmt.Errorf("configuration %s not found", key)
+// HasUncommittedChanges reports whether the working tree has uncommitted changes
+func (gs *GitService) HasUncommittedChanges(ctx context.Context) (bool, error) {
+	dirty, err := gs.backend.HasUncommittedChanges(ctx, gs.workingDir)
+	if err != nil {
+		return false, models.NewProjectError("Failed to check working tree status", err)
 	}
 
-	return nil
+	return dirty, nil
 }
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Git backend names accepted by NewGitBackend
+const (
+	GitBackendNative     = "native"
+	GitBackendSubprocess = "subprocess"
+)
+
+// GitBackend performs git operations on the repository in dir
+type GitBackend interface {
+	// Init creates a repository whose initial branch is main (re-initializing is not an error)
+	Init(ctx context.Context, dir string) error
+	// AddAll stages every change in the working tree, honoring .gitignore
+	AddAll(ctx context.Context, dir string) error
+	// Commit records the staged changes
+	Commit(ctx context.Context, dir, message string) error
+	// AddRemote adds a remote named name pointing at url
+	AddRemote(ctx context.Context, dir, name, url string) error
+	// CurrentBranch returns the checked out branch, or "" when HEAD is detached
+	CurrentBranch(ctx context.Context, dir string) (string, error)
+	// Push pushes branch to remote and makes it the branch's upstream
+	Push(ctx context.Context, dir, remote, branch string) error
+	// SetUpstream makes remote/branch the upstream of the current branch
+	SetUpstream(ctx context.Context, dir, remote, branch string) error
+	// Clone clones the repository at url into dir
+	Clone(ctx context.Context, url, dir string) error
+	// CloneGitHub clones the owner/repo GitHub repository into dir with the gh credentials
+	CloneGitHub(ctx context.Context, repository, dir string) error
+	// ResolveRevision resolves a branch, tag or commit to a commit SHA
+	ResolveRevision(ctx context.Context, dir, revision string) (string, error)
+	// Checkout checks out a commit, detaching HEAD
+	Checkout(ctx context.Context, dir, commit string) error
+	// IsBare reports whether dir is a bare repository
+	IsBare(ctx context.Context, dir string) (bool, error)
+	// TopLevel returns the root of the working tree of the repository containing dir
+	TopLevel(ctx context.Context, dir string) (string, error)
+	// HasUncommittedChanges reports whether the working tree has staged, unstaged or untracked changes
+	HasUncommittedChanges(ctx context.Context, dir string) (bool, error)
+	// ConfigureUser sets user.name and user.email in the repository when they are not configured yet
+	ConfigureUser(ctx context.Context, dir, name, email string) error
+}
+
+// GitError describes a failed git operation
+type GitError struct {
+	// Op is the git operation, such as "clone" or "push"
+	Op string
+	// Output is what the git command printed (subprocess backend only)
+	Output string
+	Err    error
+}
+
+func (e *GitError) Error() string {
+	if e.Output != "" {
+		return fmt.Sprintf("git %s: %s", e.Op, e.Output)
+	}
+	return fmt.Sprintf("git %s: %v", e.Op, e.Err)
+}
+
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	defaultGitBackendMu sync.RWMutex
+	defaultGitBackend   GitBackend = NewNativeGitBackend()
+)
+
+// NewGitBackend returns the backend with the given name; empty selects the native backend
+func NewGitBackend(name string) (GitBackend, error) {
+	switch name {
+	case "", GitBackendNative:
+		return NewNativeGitBackend(), nil
+	case GitBackendSubprocess:
+		return NewSubprocessGitBackend(), nil
+	default:
+		return nil, fmt.Errorf("unknown git backend '%s': use '%s' or '%s'", name, GitBackendNative, GitBackendSubprocess)
+	}
+}
+
+// UsesGitExecutable reports whether the backend runs the git executable
+func UsesGitExecutable(backend GitBackend) bool {
+	_, ok := backend.(subprocessGitBackend)
+	return ok
+}
+
+// DefaultGitBackend returns the backend used by NewGitService and template cloning
+func DefaultGitBackend() GitBackend {
+	defaultGitBackendMu.RLock()
+	defer defaultGitBackendMu.RUnlock()
+	return defaultGitBackend
+}
+
+// SetDefaultGitBackend replaces the backend returned by DefaultGitBackend
+func SetDefaultGitBackend(backend GitBackend) {
+	defaultGitBackendMu.Lock()
+	defer defaultGitBackendMu.Unlock()
+	defaultGitBackend = backend
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitBackend(t *testing.T) {
+	for _, name := range []string{"", GitBackendNative, GitBackendSubprocess} {
+		backend, err := NewGitBackend(name)
+		require.NoError(t, err, name)
+		assert.NotNil(t, backend)
+	}
+
+	_, err := NewGitBackend("libgit2")
+	assert.Error(t, err)
+
+	// git コマンドが必要なのは subprocess バックエンドだけ
+	assert.False(t, UsesGitExecutable(NewNativeGitBackend()))
+	assert.True(t, UsesGitExecutable(NewSubprocessGitBackend()))
+}
+
+func TestGitBackends(t *testing.T) {
+	// どちらのバックエンドでも同じ結果になること
+	for _, name := range []string{GitBackendNative, GitBackendSubprocess} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("GIT_AUTHOR_NAME", "Test User")
+			t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+			t.Setenv("GIT_COMMITTER_NAME", "Test User")
+			t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+			backend, err := NewGitBackend(name)
+			require.NoError(t, err)
+			ctx := context.Background()
+
+			tempDir := t.TempDir()
+			dir := filepath.Join(tempDir, "project")
+			remote := filepath.Join(tempDir, "remote.git")
+			require.NoError(t, exec.Command("git", "init", "--bare", "--quiet", "--initial-branch=main", remote).Run())
+
+			// 初期化直後のブランチは main
+			require.NoError(t, backend.Init(ctx, dir))
+			branch, err := backend.CurrentBranch(ctx, dir)
+			require.NoError(t, err)
+			assert.Equal(t, "main", branch)
+
+			// .gitignore の対象はコミットされない
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# project\n"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "debug.log"), []byte("log\n"), 0644))
+
+			dirty, err := backend.HasUncommittedChanges(ctx, dir)
+			require.NoError(t, err)
+			assert.True(t, dirty)
+
+			require.NoError(t, backend.AddAll(ctx, dir))
+			require.NoError(t, backend.Commit(ctx, dir, "Initial commit"))
+
+			dirty, err = backend.HasUncommittedChanges(ctx, dir)
+			require.NoError(t, err)
+			assert.False(t, dirty)
+
+			bare, err := backend.IsBare(ctx, dir)
+			require.NoError(t, err)
+			assert.False(t, bare)
+			bare, err = backend.IsBare(ctx, remote)
+			require.NoError(t, err)
+			assert.True(t, bare)
+
+			// サブディレクトリからもリポジトリのルートが返る
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "docs"), 0755))
+			topLevel, err := backend.TopLevel(ctx, filepath.Join(dir, "docs"))
+			require.NoError(t, err)
+			assert.Equal(t, canonicalPath(t, dir), canonicalPath(t, topLevel))
+
+			first, err := backend.ResolveRevision(ctx, dir, "HEAD")
+			require.NoError(t, err)
+			assert.Len(t, first, 40)
+
+			// プッシュすると上流ブランチが設定される
+			require.NoError(t, backend.AddRemote(ctx, dir, "origin", remote))
+			require.NoError(t, backend.Push(ctx, dir, "origin", "main"))
+
+			output, err := exec.Command("git", "-C", dir, "config", "branch.main.remote").Output()
+			require.NoError(t, err)
+			assert.Equal(t, "origin\n", string(output))
+
+			output, err = exec.Command("git", "--git-dir", remote, "ls-tree", "--name-only", "main").Output()
+			require.NoError(t, err)
+			assert.Equal(t, ".gitignore\nREADME.md\n", string(output))
+
+			// 注釈付きタグを付けてから2つ目のコミットを作り、クローンする
+			require.NoError(t, exec.Command("git", "-C", dir, "tag", "-a", "v1.0.0", "-m", "v1.0.0").Run())
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte("v1.1.0\n"), 0644))
+			require.NoError(t, backend.AddAll(ctx, dir))
+			require.NoError(t, backend.Commit(ctx, dir, "Add changelog"))
+			require.NoError(t, exec.Command("git", "-C", dir, "push", "--quiet", "--tags", "origin", "main").Run())
+
+			clone := filepath.Join(tempDir, "clone")
+			require.NoError(t, backend.Clone(ctx, remote, clone))
+			assert.FileExists(t, filepath.Join(clone, "CHANGELOG.md"))
+
+			tagged, err := backend.ResolveRevision(ctx, clone, "v1.0.0")
+			require.NoError(t, err)
+			assert.Equal(t, first, tagged)
+
+			require.NoError(t, backend.Checkout(ctx, clone, tagged))
+			assert.NoFileExists(t, filepath.Join(clone, "CHANGELOG.md"))
+
+			branch, err = backend.CurrentBranch(ctx, clone)
+			require.NoError(t, err)
+			assert.Empty(t, branch)
+
+			_, err = backend.ResolveRevision(ctx, clone, "missing")
+			var gitErr *GitError
+			assert.True(t, errors.As(err, &gitErr))
+		})
+	}
+}
+
+func TestGitError(t *testing.T) {
+	cause := errors.New("exit status 128")
+
+	err := &GitError{Op: "clone", Output: "fatal: repository not found", Err: cause}
+	assert.Equal(t, "git clone: fatal: repository not found", err.Error())
+	assert.ErrorIs(t, err, cause)
+
+	err = &GitError{Op: "push", Err: cause}
+	assert.Equal(t, "git push: exit status 128", err.Error())
+}
+
+// canonicalPath resolves symbolic links so paths reported by git compare equal to temporary directories
+func canonicalPath(t *testing.T, path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	require.NoError(t, err)
+	return resolved
+}
@@ -0,0 +1,280 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// nativeGitBackend performs git operations in process, without the git executable
+type nativeGitBackend struct{}
+
+// NewNativeGitBackend creates a backend that performs git operations in process
+func NewNativeGitBackend() GitBackend {
+	return nativeGitBackend{}
+}
+
+func (nativeGitBackend) Init(ctx context.Context, dir string) error {
+	_, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	if err != nil && !errors.Is(err, git.ErrRepositoryAlreadyExists) {
+		return &GitError{Op: "init", Err: err}
+	}
+	return nil
+}
+
+func (nativeGitBackend) AddAll(ctx context.Context, dir string) error {
+	worktree, err := openWorktree(dir)
+	if err == nil {
+		err = worktree.AddWithOptions(&git.AddOptions{All: true})
+	}
+	if err != nil {
+		return &GitError{Op: "add", Err: err}
+	}
+	return nil
+}
+
+func (nativeGitBackend) Commit(ctx context.Context, dir, message string) error {
+	worktree, err := openWorktree(dir)
+	if err == nil {
+		_, err = worktree.Commit(message, &git.CommitOptions{
+			Author:    signatureFromEnv("GIT_AUTHOR_NAME", "GIT_AUTHOR_EMAIL"),
+			Committer: signatureFromEnv("GIT_COMMITTER_NAME", "GIT_COMMITTER_EMAIL"),
+		})
+	}
+	if err != nil {
+		return &GitError{Op: "commit", Err: err}
+	}
+	return nil
+}
+
+func (nativeGitBackend) AddRemote(ctx context.Context, dir, name, remoteURL string) error {
+	repo, err := openRepository(dir)
+	if err == nil {
+		_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: name, URLs: []string{remoteURL}})
+	}
+	if err != nil {
+		return &GitError{Op: "remote", Err: err}
+	}
+	return nil
+}
+
+func (nativeGitBackend) CurrentBranch(ctx context.Context, dir string) (string, error) {
+	repo, err := openRepository(dir)
+	if err != nil {
+		return "", &GitError{Op: "branch", Err: err}
+	}
+
+	// Read HEAD without resolving it so that a branch without commits is reported too
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", &GitError{Op: "branch", Err: err}
+	}
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return "", nil
+	}
+	return head.Target().Short(), nil
+}
+
+func (b nativeGitBackend) Push(ctx context.Context, dir, remote, branch string) error {
+	repo, err := openRepository(dir)
+	if err != nil {
+		return &GitError{Op: "push", Err: err}
+	}
+
+	remoteConfig, err := repo.Remote(remote)
+	if err != nil {
+		return &GitError{Op: "push", Err: err}
+	}
+
+	ref := plumbing.NewBranchReferenceName(branch)
+	err = repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec(ref + ":" + ref)},
+		Auth:       credentialsFor(remoteConfig.Config().URLs[0]),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return &GitError{Op: "push", Err: err}
+	}
+
+	return b.SetUpstream(ctx, dir, remote, branch)
+}
+
+func (nativeGitBackend) SetUpstream(ctx context.Context, dir, remote, branch string) error {
+	repo, err := openRepository(dir)
+	if err == nil {
+		var cfg *gitconfig.Config
+		if cfg, err = repo.Config(); err == nil {
+			cfg.Branches[branch] = &gitconfig.Branch{
+				Name:   branch,
+				Remote: remote,
+				Merge:  plumbing.NewBranchReferenceName(branch),
+			}
+			err = repo.SetConfig(cfg)
+		}
+	}
+	if err != nil {
+		return &GitError{Op: "branch", Err: err}
+	}
+	return nil
+}
+
+func (nativeGitBackend) Clone(ctx context.Context, remoteURL, dir string) error {
+	_, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+		URL:  remoteURL,
+		Auth: credentialsFor(remoteURL),
+	})
+	if err != nil {
+		return &GitError{Op: "clone", Err: err}
+	}
+	return nil
+}
+
+func (b nativeGitBackend) CloneGitHub(ctx context.Context, repository, dir string) error {
+	host, _ := auth.DefaultHost()
+	return b.Clone(ctx, fmt.Sprintf("https://%s/%s.git", host, repository), dir)
+}
+
+func (nativeGitBackend) ResolveRevision(ctx context.Context, dir, revision string) (string, error) {
+	repo, err := openRepository(dir)
+	if err != nil {
+		return "", &GitError{Op: "rev-parse", Err: err}
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return "", &GitError{Op: "rev-parse", Err: err}
+	}
+
+	// Tags may point at tag objects; resolve them to the commit
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return "", &GitError{Op: "rev-parse", Err: err}
+	}
+	return commit.Hash.String(), nil
+}
+
+func (nativeGitBackend) Checkout(ctx context.Context, dir, commit string) error {
+	worktree, err := openWorktree(dir)
+	if err == nil {
+		err = worktree.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(commit)})
+	}
+	if err != nil {
+		return &GitError{Op: "checkout", Err: err}
+	}
+	return nil
+}
+
+func (nativeGitBackend) IsBare(ctx context.Context, dir string) (bool, error) {
+	// Unlike openRepository, a directory inside a repository is not a repository itself
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return false, &GitError{Op: "rev-parse", Err: err}
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		return false, &GitError{Op: "rev-parse", Err: err}
+	}
+	return cfg.Core.IsBare, nil
+}
+
+func (nativeGitBackend) TopLevel(ctx context.Context, dir string) (string, error) {
+	worktree, err := openWorktree(dir)
+	if err != nil {
+		return "", &GitError{Op: "rev-parse", Err: err}
+	}
+	return worktree.Filesystem.Root(), nil
+}
+
+func (nativeGitBackend) HasUncommittedChanges(ctx context.Context, dir string) (bool, error) {
+	worktree, err := openWorktree(dir)
+	if err != nil {
+		return false, &GitError{Op: "status", Err: err}
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return false, &GitError{Op: "status", Err: err}
+	}
+	return !status.IsClean(), nil
+}
+
+func (nativeGitBackend) ConfigureUser(ctx context.Context, dir, name, email string) error {
+	repo, err := openRepository(dir)
+	if err != nil {
+		return &GitError{Op: "config", Err: err}
+	}
+
+	// Settings from the global and system configuration count as configured
+	effective, err := repo.ConfigScoped(gitconfig.SystemScope)
+	if err != nil {
+		return &GitError{Op: "config", Err: err}
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return &GitError{Op: "config", Err: err}
+	}
+
+	if effective.User.Name == "" && name != "" {
+		cfg.User.Name = name
+	}
+	if effective.User.Email == "" && email != "" {
+		cfg.User.Email = email
+	}
+
+	if err := repo.SetConfig(cfg); err != nil {
+		return &GitError{Op: "config", Err: err}
+	}
+	return nil
+}
+
+// openRepository opens the repository containing dir, like git run from dir
+func openRepository(dir string) (*git.Repository, error) {
+	return git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+}
+
+// openWorktree opens the working tree of the repository containing dir
+func openWorktree(dir string) (*git.Worktree, error) {
+	repo, err := openRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	return repo.Worktree()
+}
+
+// signatureFromEnv returns the identity set by git's environment variables, or nil to use the git configuration
+func signatureFromEnv(nameKey, emailKey string) *object.Signature {
+	name, email := os.Getenv(nameKey), os.Getenv(emailKey)
+	if name == "" || email == "" {
+		return nil
+	}
+	return &object.Signature{Name: name, Email: email, When: time.Now()}
+}
+
+// credentialsFor returns the gh token of the remote's host for http(s) remotes.
+// Other remotes use the transport's defaults, such as the SSH agent.
+func credentialsFor(remote string) transport.AuthMethod {
+	u, err := url.Parse(remote)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil
+	}
+
+	token, _ := auth.TokenForHost(u.Hostname())
+	if token == "" {
+		return nil
+	}
+	return &githttp.BasicAuth{Username: "x-access-token", Password: token}
+}
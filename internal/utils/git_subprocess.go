@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// subprocessGitBackend runs the git and gh executables
+type subprocessGitBackend struct{}
+
+// NewSubprocessGitBackend creates a backend that runs the git executable (and gh for GitHub clones)
+func NewSubprocessGitBackend() GitBackend {
+	return subprocessGitBackend{}
+}
+
+func (subprocessGitBackend) Init(ctx context.Context, dir string) error {
+	if _, err := runGit(ctx, "", "init", "--quiet", dir); err != nil {
+		return err
+	}
+
+	// Set default branch to main
+	if _, err := runGit(ctx, dir, "branch", "-M", "main"); err != nil {
+		// Skip for older Git versions
+		fmt.Println("Warning: Skipped default branch configuration")
+	}
+	return nil
+}
+
+func (subprocessGitBackend) AddAll(ctx context.Context, dir string) error {
+	_, err := runGit(ctx, dir, "add", "--all")
+	return err
+}
+
+func (subprocessGitBackend) Commit(ctx context.Context, dir, message string) error {
+	_, err := runGit(ctx, dir, "commit", "--quiet", "-m", message)
+	return err
+}
+
+func (subprocessGitBackend) AddRemote(ctx context.Context, dir, name, url string) error {
+	_, err := runGit(ctx, dir, "remote", "add", name, url)
+	return err
+}
+
+func (subprocessGitBackend) CurrentBranch(ctx context.Context, dir string) (string, error) {
+	output, err := runGit(ctx, dir, "branch", "--show-current")
+	return strings.TrimSpace(output), err
+}
+
+func (subprocessGitBackend) Push(ctx context.Context, dir, remote, branch string) error {
+	_, err := runGit(ctx, dir, "push", "--quiet", "-u", remote, branch)
+	return err
+}
+
+func (subprocessGitBackend) SetUpstream(ctx context.Context, dir, remote, branch string) error {
+	_, err := runGit(ctx, dir, "branch", "--set-upstream-to", remote+"/"+branch)
+	return err
+}
+
+func (subprocessGitBackend) Clone(ctx context.Context, url, dir string) error {
+	_, err := runGit(ctx, "", "clone", "--quiet", url, dir)
+	return err
+}
+
+func (subprocessGitBackend) CloneGitHub(ctx context.Context, repository, dir string) error {
+	cmd := exec.CommandContext(ctx, "gh", "repo", "clone", repository, dir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return &GitError{Op: "clone", Output: strings.TrimSpace(string(output)), Err: err}
+	}
+	return nil
+}
+
+func (subprocessGitBackend) ResolveRevision(ctx context.Context, dir, revision string) (string, error) {
+	output, err := runGit(ctx, dir, "rev-parse", "--verify", "--quiet", revision+"^{commit}")
+	return strings.TrimSpace(output), err
+}
+
+func (subprocessGitBackend) Checkout(ctx context.Context, dir, commit string) error {
+	_, err := runGit(ctx, dir, "checkout", "--quiet", "--detach", commit)
+	return err
+}
+
+func (subprocessGitBackend) IsBare(ctx context.Context, dir string) (bool, error) {
+	output, err := runGit(ctx, dir, "rev-parse", "--is-bare-repository")
+	return strings.TrimSpace(output) == "true", err
+}
+
+func (subprocessGitBackend) TopLevel(ctx context.Context, dir string) (string, error) {
+	output, err := runGit(ctx, dir, "rev-parse", "--show-toplevel")
+	return strings.TrimSpace(output), err
+}
+
+func (subprocessGitBackend) HasUncommittedChanges(ctx context.Context, dir string) (bool, error) {
+	output, err := runGit(ctx, dir, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(output) != "", nil
+}
+
+func (subprocessGitBackend) ConfigureUser(ctx context.Context, dir, name, email string) error {
+	for _, setting := range [][2]string{{"user.name", name}, {"user.email", email}} {
+		key, value := setting[0], setting[1]
+		if current, err := runGit(ctx, dir, "config", key); err == nil && strings.TrimSpace(current) != "" {
+			continue
+		}
+		if value == "" {
+			continue
+		}
+		if _, err := runGit(ctx, dir, "config", key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runGit runs git in dir and returns its standard output. Failures carry what git printed.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", &GitError{Op: args[0], Output: strings.TrimSpace(stderr.String()), Err: err}
+	}
+	return string(output), nil
+}
@@ -120,3 +120,24 @@ func BenchmarkGitService_CheckGitInstallation(b *testing.B) {
 		_ = gitService.CheckGitInstallation()
 	}
 }
+
+func TestGitService_HasUncommittedChanges(t *testing.T) {
+	tempDir := t.TempDir()
+	gitService := NewGitService(tempDir)
+	ctx := context.Background()
+
+	require.NoError(t, gitService.InitializeRepository(ctx))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "README.md"), []byte("# Test Project"), 0644))
+
+	// 未追跡ファイルも変更として扱う
+	dirty, err := gitService.HasUncommittedChanges(ctx)
+	require.NoError(t, err)
+	assert.True(t, dirty)
+
+	require.NoError(t, gitService.AddAllFiles(ctx))
+	require.NoError(t, gitService.CreateInitialCommit(ctx, "Initial commit"))
+
+	dirty, err = gitService.HasUncommittedChanges(ctx)
+	require.NoError(t, err)
+	assert.False(t, dirty)
+}
@@ -0,0 +1,136 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/utils"
+)
+
+// scpLikeURL matches git's scp-like remote syntax such as git@host:owner/repo.git
+var scpLikeURL = regexp.MustCompile(`^[\w.-]+@[\w.-]+:`)
+
+// IsGitURL reports whether the reference is a git remote URL (https, ssh, git or scp-like)
+func IsGitURL(ref string) bool {
+	for _, scheme := range []string{"https://", "http://", "ssh://", "git://"} {
+		if strings.HasPrefix(ref, scheme) {
+			return true
+		}
+	}
+	return scpLikeURL.MatchString(ref)
+}
+
+// GitTemplate creates a template cloned from a git URL at ref (empty for the default branch)
+func GitTemplate(url, ref string) *models.Template {
+	name := strings.TrimSuffix(path.Base(strings.TrimSuffix(url, "/")), ".git")
+	if _, repo, ok := strings.Cut(name, ":"); ok {
+		// scp-like URL without a directory, e.g. git@host:repo.git
+		name = repo
+	}
+
+	return &models.Template{
+		Name:        name,
+		Description: url,
+		Topics:      []string{},
+		IsTemplate:  true,
+		CloneURL:    url,
+		Ref:         ref,
+		Source:      models.SourceGit,
+	}
+}
+
+// CloneTemplate clones the template repository with its history into dir and checks out the template's ref
+func CloneTemplate(ctx context.Context, template *models.Template, dir string) error {
+	backend := utils.DefaultGitBackend()
+
+	var err error
+	if template.FullName != "" && (template.CloneURL == "" || !isLocalPath(template.CloneURL)) {
+		err = backend.CloneGitHub(ctx, template.FullName, dir)
+	} else {
+		err = backend.Clone(ctx, template.CloneURL, dir)
+	}
+	if err != nil {
+		return models.NewGitHubError("Failed to clone template repository", err)
+	}
+
+	if template.Ref != "" {
+		return checkoutRef(ctx, dir, template.Ref)
+	}
+	return nil
+}
+
+// TemplateSourceDir returns the directory holding the template inside its checkout at root:
+// the template's subdirectory, or root itself
+func TemplateSourceDir(root string, template *models.Template) (string, error) {
+	if template == nil || template.Path == "" {
+		return root, nil
+	}
+
+	dir := filepath.Join(root, filepath.FromSlash(template.Path))
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return "", models.NewValidationError(
+			fmt.Sprintf("Template path '%s' does not exist in %s", template.Path, template.GetReference()),
+		)
+	}
+	return dir, nil
+}
+
+// checkoutRef checks out a branch, tag or commit of the freshly cloned repository at dir
+func checkoutRef(ctx context.Context, dir, ref string) error {
+	commit, err := resolveTemplateRef(ctx, dir, ref)
+	if err != nil {
+		return err
+	}
+	return checkoutCommit(ctx, dir, commit)
+}
+
+// resolveTemplateRef resolves a branch, tag or commit of a cloned template to a commit SHA.
+// Branches other than the default one only exist as remote-tracking branches in a fresh clone.
+func resolveTemplateRef(ctx context.Context, dir, ref string) (string, error) {
+	commit, err := ResolveCommit(ctx, dir, ref)
+	if err == nil || ref == "" {
+		return commit, err
+	}
+
+	if commit, remoteErr := ResolveCommit(ctx, dir, "origin/"+ref); remoteErr == nil {
+		return commit, nil
+	}
+	return "", err
+}
+
+// ResolveCommit resolves a ref to a commit SHA in the repository at dir
+func ResolveCommit(ctx context.Context, dir, ref string) (string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	commit, err := utils.DefaultGitBackend().ResolveRevision(ctx, dir, ref)
+	if err != nil {
+		return "", models.NewValidationError(fmt.Sprintf("Unknown template ref '%s'", ref))
+	}
+
+	return commit, nil
+}
+
+// checkoutCommit checks out a commit in the repository at dir
+func checkoutCommit(ctx context.Context, dir, commit string) error {
+	if err := utils.DefaultGitBackend().Checkout(ctx, dir, commit); err != nil {
+		return models.NewProjectError(fmt.Sprintf("failed to check out template commit %s", ShortCommit(commit)), err)
+	}
+	return nil
+}
+
+// ShortCommit abbreviates a commit SHA for display
+func ShortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
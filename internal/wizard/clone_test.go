@@ -0,0 +1,285 @@
+package wizard
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBareRepository は main・feature ブランチと v1 タグを持つベアリポジトリを作成する
+func newTestBareRepository(t *testing.T) (string, map[string]string) {
+	workDir := t.TempDir()
+	runTestGit(t, workDir, "init", "--quiet", "--initial-branch=main")
+	commits := map[string]string{}
+	commits["v1"] = commitTestTemplate(t, workDir, map[string]string{"VERSION": "v1\n"})
+	runTestGit(t, workDir, "tag", "v1")
+	commits["main"] = commitTestTemplate(t, workDir, map[string]string{"VERSION": "main\n"})
+
+	runTestGit(t, workDir, "checkout", "--quiet", "-b", "feature")
+	commits["feature"] = commitTestTemplate(t, workDir, map[string]string{
+		"VERSION":               "feature\n",
+		"README.md":             "# {{.Name}}\n",
+		models.ManifestFileName: "variables:\n  - name: port\n    type: int\n    default: 8080\n",
+	})
+	runTestGit(t, workDir, "checkout", "--quiet", "main")
+
+	bareDir := filepath.Join(t.TempDir(), "service.git")
+	runTestGit(t, workDir, "clone", "--quiet", "--bare", workDir, bareDir)
+	return bareDir, commits
+}
+
+func TestIsGitURL(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected bool
+	}{
+		{"https://gitea.example.com/platform/service.git", true},
+		{"http://gitea.local/platform/service", true},
+		{"ssh://git@gitea.example.com:2222/platform/service.git", true},
+		{"git://example.com/service.git", true},
+		{"git@gitea.example.com:platform/service.git", true},
+		{"acme/service", false},
+		{"./templates/service", false},
+		{"/opt/templates/service.git", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsGitURL(tt.ref))
+		})
+	}
+}
+
+func TestGitTemplate(t *testing.T) {
+	template := GitTemplate("git@gitea.example.com:platform/service.git", "develop")
+	assert.Equal(t, "service", template.Name)
+	assert.Equal(t, "develop", template.Ref)
+	assert.Equal(t, models.SourceGit, template.Source)
+	assert.Empty(t, template.FullName)
+
+	assert.Equal(t, "service", GitTemplate("git@gitea.example.com:service.git", "").Name)
+	assert.Equal(t, "service", GitTemplate("https://gitea.example.com/platform/service/", "").Name)
+}
+
+func TestCloneTemplate_Ref(t *testing.T) {
+	bareDir, commits := newTestBareRepository(t)
+
+	tests := []struct {
+		ref      string
+		expected string
+	}{
+		{"", "main\n"},
+		{"feature", "feature\n"}, // クローン直後はリモート追跡ブランチにしかない
+		{"v1", "v1\n"},
+		{commits["v1"], "v1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "clone")
+			require.NoError(t, CloneTemplate(context.Background(), GitTemplate(bareDir, tt.ref), dir))
+			assert.Equal(t, tt.expected, readTestFile(t, filepath.Join(dir, "VERSION")))
+		})
+	}
+
+	// 存在しない ref はエラー
+	err := CloneTemplate(context.Background(), GitTemplate(bareDir, "missing"), filepath.Join(t.TempDir(), "clone"))
+	assert.ErrorContains(t, err, "Unknown template ref 'missing'")
+}
+
+func TestProjectExecutor_GitTemplate(t *testing.T) {
+	bareDir, commits := newTestBareRepository(t)
+
+	// ベアリポジトリのパスは git テンプレートとして扱う
+	template, err := LocalTemplate(bareDir)
+	require.NoError(t, err)
+	assert.Equal(t, models.SourceGit, template.Source)
+	template.Ref = "feature"
+
+	// ref のマニフェストを読み込める
+	manifest, err := LoadTemplateManifest(context.Background(), github.NewSimpleMockClient(), template)
+	require.NoError(t, err)
+	require.NotNil(t, manifest)
+
+	projectPath := filepath.Join(t.TempDir(), "billing")
+	config := &models.ProjectConfig{
+		Name:      "billing",
+		LocalPath: projectPath,
+		Template:  template,
+		Manifest:  manifest,
+		Variables: map[string]interface{}{"port": 8080},
+	}
+	require.NoError(t, NewProjectExecutor(github.NewSimpleMockClient()).Execute(context.Background(), config))
+
+	// feature ブランチの内容で生成し、ref とコミットを記録する
+	assert.Equal(t, "feature\n", readTestFile(t, filepath.Join(projectPath, "VERSION")))
+	assert.Equal(t, "# billing\n", readTestFile(t, filepath.Join(projectPath, "README.md")))
+	_, err = os.Stat(filepath.Join(projectPath, models.ManifestFileName))
+	assert.True(t, os.IsNotExist(err))
+
+	lock, err := ReadProjectLock(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, bareDir, lock.CloneURL)
+	assert.Equal(t, "feature", lock.Ref)
+	assert.Equal(t, commits["feature"], lock.Commit)
+}
+
+func TestTemplateUpdater_FollowsRef(t *testing.T) {
+	bareDir, commits := newTestBareRepository(t)
+
+	// v1 タグから生成したプロジェクト
+	template := GitTemplate(bareDir, "v1")
+	projectDir := filepath.Join(t.TempDir(), "billing")
+	config := &models.ProjectConfig{Name: "billing", LocalPath: projectDir, Template: template}
+	require.NoError(t, NewProjectExecutor(github.NewSimpleMockClient()).Execute(context.Background(), config))
+
+	lock, err := ReadProjectLock(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, commits["v1"], lock.Commit)
+
+	// ref を省略すると記録した ref に従う
+	result, err := NewTemplateUpdater(projectDir).Update(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, result.UpToDate())
+
+	// 別のブランチに更新すると、以降はそのブランチに従う
+	result, err = NewTemplateUpdater(projectDir).Update(context.Background(), "feature")
+	require.NoError(t, err)
+	assert.Equal(t, commits["feature"], result.ToCommit)
+	assert.Equal(t, "feature\n", readTestFile(t, filepath.Join(projectDir, "VERSION")))
+
+	lock, err = ReadProjectLock(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, "feature", lock.Ref)
+}
+
+func TestProjectExecutor_SubdirTemplate(t *testing.T) {
+	workDir := t.TempDir()
+	runTestGit(t, workDir, "init", "--quiet", "--initial-branch=main")
+	commitTestTemplate(t, workDir, map[string]string{
+		models.ManifestFileName:                  "templates:\n  - name: go-service\n    path: services/go\n",
+		"README.md":                              "# templates\n",
+		"services/go/main.go":                    "package main\n",
+		"services/go/" + models.ManifestFileName: "variables:\n  - name: port\n    type: int\n    default: 8080\n",
+		"services/go/config/{{.Name}}.yaml":      "port: {{.Vars.port}}\n",
+	})
+	bareDir := filepath.Join(t.TempDir(), "templates.git")
+	runTestGit(t, workDir, "clone", "--quiet", "--bare", workDir, bareDir)
+
+	template := GitTemplate(bareDir, "").WithPath("services/go")
+	manifest, err := LoadTemplateManifest(context.Background(), github.NewSimpleMockClient(), &template)
+	require.NoError(t, err)
+	require.NotNil(t, manifest)
+
+	projectPath := filepath.Join(t.TempDir(), "billing")
+	config := &models.ProjectConfig{
+		Name:      "billing",
+		LocalPath: projectPath,
+		Template:  &template,
+		Manifest:  manifest,
+		Variables: map[string]interface{}{"port": 9090},
+	}
+	require.NoError(t, NewProjectExecutor(github.NewSimpleMockClient()).Execute(context.Background(), config))
+
+	// サブディレクトリだけをコピーする
+	assert.Equal(t, "package main\n", readTestFile(t, filepath.Join(projectPath, "main.go")))
+	assert.Equal(t, "port: 9090\n", readTestFile(t, filepath.Join(projectPath, "config", "billing.yaml")))
+	assert.NoFileExists(t, filepath.Join(projectPath, "README.md"))
+	assert.NoFileExists(t, filepath.Join(projectPath, models.ManifestFileName))
+
+	lock, err := ReadProjectLock(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, "services/go", lock.Path)
+
+	// 更新もサブディレクトリから行う
+	commitTestTemplate(t, workDir, map[string]string{
+		"README.md":           "# templates v2\n",
+		"services/go/main.go": "package main\n\nfunc main() {}\n",
+	})
+	runTestGit(t, workDir, "push", "--quiet", bareDir, "main")
+
+	result, err := NewTemplateUpdater(projectPath).Update(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, []FileUpdate{{Path: "main.go", Action: UpdateActionUpdated}}, result.Files)
+	assert.Equal(t, "package main\n\nfunc main() {}\n", readTestFile(t, filepath.Join(projectPath, "main.go")))
+	assert.NoFileExists(t, filepath.Join(projectPath, "README.md"))
+
+	// 存在しないサブディレクトリはエラー
+	missing := GitTemplate(bareDir, "").WithPath("services/rust")
+	config = &models.ProjectConfig{Name: "other", LocalPath: filepath.Join(t.TempDir(), "other"), Template: &missing}
+	err = NewProjectExecutor(github.NewSimpleMockClient()).Execute(context.Background(), config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Template path 'services/rust' does not exist")
+}
+
+func TestProjectExecutor_LocalTemplateLock(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T) string
+		wantLock bool
+	}{
+		{
+			name: "クリーンなリポジトリのルートは記録する",
+			setup: func(t *testing.T) string {
+				dir := t.TempDir()
+				runTestGit(t, dir, "init", "--quiet")
+				commitTestTemplate(t, dir, map[string]string{"main.go": "package main\n"})
+				return dir
+			},
+			wantLock: true,
+		},
+		{
+			name: "未コミットの変更があると記録しない",
+			setup: func(t *testing.T) string {
+				dir := t.TempDir()
+				runTestGit(t, dir, "init", "--quiet")
+				commitTestTemplate(t, dir, map[string]string{"main.go": "package main\n"})
+				writeTestFiles(t, dir, map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+				return dir
+			},
+		},
+		{
+			name: "別のリポジトリ内のディレクトリは記録しない",
+			setup: func(t *testing.T) string {
+				dir := t.TempDir()
+				runTestGit(t, dir, "init", "--quiet")
+				commitTestTemplate(t, dir, map[string]string{"templates/service/main.go": "package main\n"})
+				return filepath.Join(dir, "templates", "service")
+			},
+		},
+		{
+			name: "git リポジトリでなければ記録しない",
+			setup: func(t *testing.T) string {
+				dir := t.TempDir()
+				writeTestFiles(t, dir, map[string]string{"main.go": "package main\n"})
+				return dir
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateDir := tt.setup(t)
+			projectPath := filepath.Join(t.TempDir(), "billing")
+			config := &models.ProjectConfig{
+				Name:      "billing",
+				LocalPath: projectPath,
+				Template:  &models.Template{Name: "service", CloneURL: templateDir, Source: models.SourceLocal},
+			}
+			require.NoError(t, NewProjectExecutor(nil).Execute(context.Background(), config))
+
+			assert.FileExists(t, filepath.Join(projectPath, "main.go"))
+			if tt.wantLock {
+				assert.FileExists(t, filepath.Join(projectPath, models.LockFileName))
+			} else {
+				assert.NoFileExists(t, filepath.Join(projectPath, models.LockFileName))
+			}
+		})
+	}
+}
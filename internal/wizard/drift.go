@@ -0,0 +1,161 @@
+package wizard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// DriftReport describes how a project differs from its template.
+// Added files exist only in the template, removed files were dropped by the template
+// since generation but remain in the project, and modified files differ in content.
+type DriftReport struct {
+	Template       string   `json:"template"`
+	RecordedCommit string   `json:"recorded_commit"`
+	TemplateCommit string   `json:"template_commit"`
+	Added          []string `json:"added"`
+	Removed        []string `json:"removed"`
+	Modified       []string `json:"modified"`
+	Diff           string   `json:"-"`
+}
+
+// HasDrift reports whether the project differs from the template
+func (dr *DriftReport) HasDrift() bool {
+	return len(dr.Added)+len(dr.Removed)+len(dr.Modified) > 0
+}
+
+// Diff renders the template at ref with the recorded answers and compares it with the project.
+// It never modifies the project.
+func (tu *TemplateUpdater) Diff(ctx context.Context, ref string) (*DriftReport, error) {
+	if err := requireGitExecutable(); err != nil {
+		return nil, err
+	}
+
+	lock, err := ReadProjectLock(tu.projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := os.MkdirTemp("", "gh-wizard-diff-*")
+	if err != nil {
+		return nil, models.NewProjectError("failed to create temporary directory", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	cloneDir, target, err := tu.cloneRecordedTemplate(ctx, lock, workDir, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// The recorded render tells files the template dropped apart from files the project added
+	baseDir := filepath.Join(workDir, "base")
+	if _, err := renderTemplateAt(ctx, cloneDir, lock.Commit, lock, baseDir); err != nil {
+		return nil, err
+	}
+	headDir := filepath.Join(workDir, "head")
+	if _, err := renderTemplateAt(ctx, cloneDir, target, lock, headDir); err != nil {
+		return nil, err
+	}
+
+	baseFiles, err := listFiles(baseDir)
+	if err != nil {
+		return nil, models.NewProjectError("failed to read rendered template", err)
+	}
+	headFiles, err := listFiles(headDir)
+	if err != nil {
+		return nil, models.NewProjectError("failed to read rendered template", err)
+	}
+
+	template := lock.GetTemplate()
+	report := &DriftReport{
+		Template:       template.Name,
+		RecordedCommit: lock.Commit,
+		TemplateCommit: target,
+		Added:          []string{},
+		Removed:        []string{},
+		Modified:       []string{},
+	}
+	if template.FullName != "" {
+		report.Template = template.GetReference()
+	}
+
+	var diff strings.Builder
+	for _, relPath := range sortedPaths(baseFiles, headFiles) {
+		nativePath := filepath.FromSlash(relPath)
+		projectPath := filepath.Join(tu.projectDir, nativePath)
+		templatePath := filepath.Join(headDir, nativePath)
+
+		current, hasCurrent, err := readOptionalFile(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		upstream, hasUpstream, err := readOptionalFile(templatePath)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case hasUpstream && !hasCurrent:
+			report.Added = append(report.Added, relPath)
+			projectPath = ""
+		case hasUpstream && !bytes.Equal(current, upstream):
+			report.Modified = append(report.Modified, relPath)
+		case !hasUpstream && hasCurrent:
+			report.Removed = append(report.Removed, relPath)
+			templatePath = ""
+		default:
+			continue
+		}
+
+		fileDiff, err := unifiedDiff(ctx, relPath, projectPath, templatePath)
+		if err != nil {
+			return nil, models.NewProjectError(fmt.Sprintf("failed to diff %s", relPath), err)
+		}
+		diff.WriteString(fileDiff)
+	}
+	report.Diff = diff.String()
+
+	return report, nil
+}
+
+// unifiedDiff diffs the project file (a/) against the template file (b/); an empty path means the file is absent
+func unifiedDiff(ctx context.Context, relPath, projectPath, templatePath string) (string, error) {
+	oldPath, oldLabel := projectPath, "a/"+relPath
+	if oldPath == "" {
+		oldPath, oldLabel = os.DevNull, "/dev/null"
+	}
+	newPath, newLabel := templatePath, "b/"+relPath
+	if newPath == "" {
+		newPath, newLabel = os.DevNull, "/dev/null"
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "diff", "--no-index", "--no-color", "--no-ext-diff", "--", oldPath, newPath)
+	output, err := cmd.Output()
+
+	// git diff exits with 1 when the files differ
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return "", err
+	}
+
+	// Replace git's headers, which name the temporary files, with project-relative paths
+	var result strings.Builder
+	fmt.Fprintf(&result, "diff --git a/%s b/%s\n", relPath, relPath)
+
+	body := string(output)
+	if index := strings.Index(body, "\n@@"); index != -1 {
+		fmt.Fprintf(&result, "--- %s\n+++ %s\n", oldLabel, newLabel)
+		result.WriteString(body[index+1:])
+	} else if strings.Contains(body, "\nBinary files ") {
+		fmt.Fprintf(&result, "Binary files %s and %s differ\n", oldLabel, newLabel)
+	}
+
+	return result.String(), nil
+}
@@ -0,0 +1,70 @@
+package wizard
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateUpdater_Diff(t *testing.T) {
+	templateDir := t.TempDir()
+	runTestGit(t, templateDir, "init", "--quiet")
+	firstCommit := commitTestTemplate(t, templateDir, map[string]string{
+		models.ManifestFileName: "variables: []\n",
+		"README.md":             "# {{.Name}}\n",
+		"old.txt":               "old\n",
+	})
+
+	template := &models.Template{Name: "service", CloneURL: templateDir}
+	config := &models.ProjectConfig{Name: "billing", Template: template, Manifest: &models.TemplateManifest{}}
+	projectDir := filepath.Join(t.TempDir(), "billing")
+	require.NoError(t, NewTemplateRenderer(config).CopyTree(templateDir, projectDir, []string{".git", models.ManifestFileName}))
+	require.NoError(t, WriteProjectLock(projectDir, models.NewProjectLock(config, firstCommit)))
+
+	// 生成直後は差分なし
+	report, err := NewTemplateUpdater(projectDir).Diff(context.Background(), "")
+	require.NoError(t, err)
+	assert.False(t, report.HasDrift())
+	assert.Empty(t, report.Diff)
+
+	commitTestTemplate(t, templateDir, map[string]string{
+		"README.md": "# {{.Name}}\n\nUpdated\n",
+		"new.txt":   "new\n",
+	}, "old.txt")
+	// プロジェクトだけのファイルは差分に含めない
+	writeTestFiles(t, projectDir, map[string]string{"main.go": "package main\n"})
+
+	report, err = NewTemplateUpdater(projectDir).Diff(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, report.HasDrift())
+	assert.Equal(t, firstCommit, report.RecordedCommit)
+	assert.Equal(t, []string{"new.txt"}, report.Added)
+	assert.Equal(t, []string{"old.txt"}, report.Removed)
+	assert.Equal(t, []string{"README.md"}, report.Modified)
+
+	assert.Contains(t, report.Diff, "--- a/README.md\n+++ b/README.md\n")
+	assert.Contains(t, report.Diff, "+Updated\n")
+	assert.Contains(t, report.Diff, "--- /dev/null\n+++ b/new.txt\n")
+	assert.Contains(t, report.Diff, "--- a/old.txt\n+++ /dev/null\n")
+	assert.NotContains(t, report.Diff, os.TempDir())
+
+	// 読み取り専用
+	assert.NoFileExists(t, filepath.Join(projectDir, "new.txt"))
+	lock, err := ReadProjectLock(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, firstCommit, lock.Commit)
+}
+
+func TestTemplateUpdater_DiffLegacyTemplate(t *testing.T) {
+	_, projectDir := createLegacyProject(t)
+
+	// マニフェストのないテンプレートから作成した直後は差分がない
+	report, err := NewTemplateUpdater(projectDir).Diff(context.Background(), "")
+	require.NoError(t, err)
+	assert.False(t, report.HasDrift())
+}
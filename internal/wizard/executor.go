@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strings"
 
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
@@ -16,6 +14,7 @@ import (
 // ProjectExecutor executes project creation
 type ProjectExecutor struct {
 	githubClient github.Client
+	hooksEnabled bool
 }
 
 // NewProjectExecutor creates a new Executor
@@ -25,329 +24,380 @@ func NewProjectExecutor(githubClient github.Client) *ProjectExecutor {
 	}
 }
 
-// Execute executes project creation
-func (pe *ProjectExecutor) Execute(ctx context.Context, config *models.ProjectConfig) error {
-	// 1. Create local directory
-	fmt.Println("✓ Creating directory from template...")
-	if err := pe.createLocalDirectory(ctx, config); err != nil {
-		return fmt.Errorf("failed to create local directory: %w", err)
-	}
-
-	// 2. Create GitHub repository (optional)
-	if config.CreateGitHub {
-		fmt.Println("✓ Creating GitHub repository...")
-		if err := pe.createGitHubRepository(ctx, config); err != nil {
-			return fmt.Errorf("failed to create GitHub repository: %w", err)
-		}
+// SetHooksEnabled enables running template hooks (only after the user trusted them)
+func (pe *ProjectExecutor) SetHooksEnabled(enabled bool) {
+	pe.hooksEnabled = enabled
+}
 
-		fmt.Println("✓ Pushing local repository...")
-		if err := pe.pushToGitHub(ctx, config); err != nil {
-			return fmt.Errorf("failed to push to GitHub: %w", err)
-		}
+// RunPreGenerateHooks runs the template's pre-generate hooks in the template's directory before anything is written,
+// so they behave the same wherever gh-wizard is launched. Remote templates are checked out into a temporary directory.
+// It is separate from Execute so variables derived by the hooks can be reviewed before creation.
+func (pe *ProjectExecutor) RunPreGenerateHooks(ctx context.Context, config *models.ProjectConfig) error {
+	if !pe.hooksEnabled || config.Template == nil || config.Manifest == nil || len(config.Manifest.Hooks.PreGenerate) == 0 {
+		return nil
 	}
 
-	fmt.Println("✅ Complete! Your project is ready")
-	pe.printSuccess(config)
+	checkoutDir, cleanup, err := checkoutTemplate(ctx, config.Template)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 
-	return nil
+	sourceDir, err := TemplateSourceDir(checkoutDir, config.Template)
+	if err != nil {
+		return err
+	}
+	return NewHookRunner(sourceDir).RunPreGenerate(ctx, config.Manifest.Hooks.PreGenerate, config)
 }
 
-// createLocalDirectory creates a local directory from template
-func (pe *ProjectExecutor) createLocalDirectory(ctx context.Context, config *models.ProjectConfig) error {
-	targetPath := config.GetLocalCreatePath()
+// Execute executes project creation: the local project, its post-create hooks and the GitHub repository.
+// Pre-generate hooks are not run here; see RunPreGenerateHooks.
+func (pe *ProjectExecutor) Execute(ctx context.Context, config *models.ProjectConfig) error {
+	fmt.Printf("🚀 Creating project '%s'...\n", config.Name)
 
-	// Check if directory already exists
-	if _, err := os.Stat(targetPath); !os.IsNotExist(err) {
-		return models.NewProjectError(
-			fmt.Sprintf("directory '%s' already exists", targetPath),
-			nil,
-		)
+	// Check for context cancellation
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	if config.HasTemplate() {
-		return pe.cloneFromTemplate(ctx, config)
+	var repoInfo *github.RepositoryInfo
+	var err error
+	if config.GenerateOnGitHub {
+		repoInfo, err = pe.generateProject(ctx, config)
 	} else {
-		return pe.createEmptyProject(ctx, config)
+		repoInfo, err = pe.createProject(ctx, config)
+	}
+	if err != nil {
+		return err
 	}
+
+	pe.printSuccess(config, repoInfo)
+	return nil
 }
 
-// createEmptyProject creates an empty project
-func (pe *ProjectExecutor) createEmptyProject(ctx context.Context, config *models.ProjectConfig) error {
-	targetPath := config.GetLocalCreatePath()
+// createProject creates the project locally, then its GitHub repository when requested
+func (pe *ProjectExecutor) createProject(ctx context.Context, config *models.ProjectConfig) (*github.RepositoryInfo, error) {
+	// 1. Create local directory from the template
+	if err := pe.createLocalDirectory(ctx, config); err != nil {
+		return nil, err
+	}
 
-	// Create directory
-	if err := os.MkdirAll(targetPath, 0755); err != nil {
-		return models.NewProjectError(
-			"failed to create directory",
-			err,
-		)
+	// 2. Run post-create hooks declared by the template
+	if err := pe.runPostCreateHooks(ctx, config); err != nil {
+		return nil, err
 	}
 
-	// Create README.md
-	readmePath := filepath.Join(targetPath, "README.md")
-	readmeContent := fmt.Sprintf("# %s\n\n%s\n", config.Name, config.Description)
+	// 3. Create GitHub repository (if applicable)
+	if !config.CreateGitHub {
+		return nil, nil
+	}
 
-	if err := os.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
-		return models.NewProjectError(
-			"failed to create README.md",
-			err,
-		)
+	// Check for context cancellation
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	// Initialize as Git repository
-	return pe.initializeGitRepository(ctx, targetPath)
+	fmt.Printf("🐙 Creating GitHub repository...\n")
+	return pe.createGitHubRepository(ctx, config)
 }
 
-// printSuccess displays success message
-func (pe *ProjectExecutor) printSuccess(config *models.ProjectConfig) {
-	fmt.Println()
-	fmt.Println("🎉 Project created successfully!")
-	fmt.Println()
-
-	// Display project information
-	summary := config.GetDisplaySummary()
-	for _, line := range summary {
-		fmt.Printf("  %s\n", line)
+// generateProject creates the repository from the template on GitHub, clones it and pushes the rendered template
+func (pe *ProjectExecutor) generateProject(ctx context.Context, config *models.ProjectConfig) (*github.RepositoryInfo, error) {
+	fmt.Printf("🐙 Generating GitHub repository from template '%s'...\n", config.Template.GetReference())
+	repoInfo, err := GenerateProject(ctx, pe.githubClient, config)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Println()
-
-	// Suggest next steps
-	fmt.Println("📝 Next steps:")
-	fmt.Printf("  cd %s\n", config.Name)
+	if err := pe.grantTeams(ctx, config, repoInfo); err != nil {
+		return nil, err
+	}
 
-	if config.Template != nil && config.Template.Language != "" {
-		switch config.Template.Language {
-		case "JavaScript", "TypeScript":
-			fmt.Println("  npm install")
-			fmt.Println("  npm run dev")
-		case "Go":
-			fmt.Println("  go mod tidy")
-			fmt.Println("  go run main.go")
-		case "Python":
-			fmt.Println("  pip install -r requirements.txt")
-			fmt.Println("  python main.py")
-		default:
-			fmt.Println("  # Please check the project README")
-		}
+	if err := pe.runPostCreateHooks(ctx, config); err != nil {
+		return nil, err
 	}
 
-	if config.CreateGitHub {
-		repoURL := fmt.Sprintf("https://github.com/%s/%s", getCurrentUser(), config.Name)
-		fmt.Printf("\n🔗 GitHub repository: %s\n", repoURL)
+	if err := PushGeneratedProject(ctx, config); err != nil {
+		return nil, err
 	}
-}
 
-// getCurrentUser gets the current GitHub username
-func getCurrentUser() string {
-	cmd := exec.Command("gh", "api", "user", "--jq", ".login")
-	output, err := cmd.Output()
-	if err != nil {
-		return "unknown" // Fallback
+	if err := pe.applyRepositorySettings(ctx, config, repoInfo); err != nil {
+		return nil, err
 	}
-	return strings.TrimSpace(string(output))
+	return repoInfo, nil
 }
 
-// cloneFromTemplate creates a project from template
-func (pe *ProjectExecutor) cloneFromTemplate(ctx context.Context, config *models.ProjectConfig) error {
-	targetPath := config.GetLocalCreatePath()
+// createGitHubRepository creates a GitHub repository and pushes the project to it
+func (pe *ProjectExecutor) createGitHubRepository(ctx context.Context, config *models.ProjectConfig) (*github.RepositoryInfo, error) {
+	gitService := utils.NewGitService(config.GetLocalCreatePath())
 
-	// Create from template using GitHub CLI
-	repoURL := config.Template.CloneURL
-	if repoURL == "" {
-		repoURL = config.Template.GetRepoURL()
+	// Initial commit and file addition
+	if err := gitService.AddAllFiles(ctx); err != nil {
+		return nil, models.NewValidationError(fmt.Sprintf("Failed to stage files: %v", err))
 	}
-
-	cmd := exec.CommandContext(ctx, "gh", "repo", "create", config.Name,
-		"--template", config.Template.FullName,
-		"--clone",
-		"--local",
-		"--destination", targetPath)
-
-	if config.IsPrivate {
-		cmd.Args = append(cmd.Args, "--private")
-	} else {
-		cmd.Args = append(cmd.Args, "--public")
+	if err := gitService.CreateInitialCommit(ctx, "Initial commit"); err != nil {
+		return nil, models.NewValidationError(fmt.Sprintf("Failed to create initial commit: %v", err))
 	}
 
-	if config.Description != "" {
-		cmd.Args = append(cmd.Args, "--description", config.Description)
+	// 1. Create GitHub repository and give the teams access right away
+	repoInfo, err := pe.githubClient.CreateRepository(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := pe.grantTeams(ctx, config, repoInfo); err != nil {
+		return nil, err
 	}
 
-	if err := cmd.Run(); err != nil {
-		// If GitHub CLI fails, try regular git clone
-		return pe.fallbackClone(ctx, config)
+	// 2. Add remote repository
+	if err := gitService.AddRemote(ctx, "origin", repoInfo.CloneURL); err != nil {
+		return nil, models.NewValidationError(fmt.Sprintf("Failed to add remote repository: %v", err))
 	}
 
-	return nil
-}
+	// 3. Get current branch name
+	currentBranch, err := gitService.GetCurrentBranch(ctx)
+	if err != nil {
+		return nil, models.NewValidationError(fmt.Sprintf("Failed to get current branch name: %v", err))
+	}
 
-// fallbackClone is fallback when GitHub CLI fails
-func (pe *ProjectExecutor) fallbackClone(ctx context.Context, config *models.ProjectConfig) error {
-	targetPath := config.GetLocalCreatePath()
-	repoURL := config.Template.CloneURL
-	if repoURL == "" {
-		repoURL = config.Template.GetRepoURL()
+	// 4. Push current branch
+	if err := gitService.PushToRemote(ctx, "origin", currentBranch); err != nil {
+		return nil, models.NewGitHubError(fmt.Sprintf("Failed to push to repository %s", repoInfo.HTMLURL), err)
 	}
 
-	// For local directories, perform copy operation
-	if isLocalPath(repoURL) {
-		return pe.copyFromLocalTemplate(repoURL, targetPath)
+	// 5. Apply repository settings once the branches exist
+	if err := pe.applyRepositorySettings(ctx, config, repoInfo); err != nil {
+		return nil, err
 	}
+	return repoInfo, nil
+}
 
-	cmd := exec.CommandContext(ctx, "git", "clone", repoURL, targetPath)
-	if err := cmd.Run(); err != nil {
-		return models.NewProjectError(
-			fmt.Sprintf("failed to clone template: %s", repoURL),
-			err,
-		)
+// grantTeams gives the project's teams access to the repository right after it is created
+func (pe *ProjectExecutor) grantTeams(ctx context.Context, config *models.ProjectConfig, repoInfo *github.RepositoryInfo) error {
+	if len(config.Teams) == 0 {
+		return nil
 	}
 
-	// Remove .git directory and initialize as new repository
-	gitDir := filepath.Join(targetPath, ".git")
-	if err := os.RemoveAll(gitDir); err != nil {
-		return models.NewProjectError("failed to remove existing .git directory", err)
+	fmt.Printf("👥 Granting team access to %s...\n", repoInfo.FullName)
+	return GrantTeams(ctx, pe.githubClient, config, repoInfo)
+}
+
+// applyRepositorySettings applies the configured repository settings to the new repository
+func (pe *ProjectExecutor) applyRepositorySettings(ctx context.Context, config *models.ProjectConfig, repoInfo *github.RepositoryInfo) error {
+	if config.RepoSettings.IsEmpty() {
+		return nil
 	}
 
-	// Initialize as new Git repository
-	return pe.initializeGitRepository(ctx, targetPath)
+	fmt.Printf("⚙️  Applying repository settings to %s...\n", repoInfo.FullName)
+	return pe.githubClient.ApplyRepositorySettings(ctx, repoInfo.FullName, config.RepoSettings)
 }
 
-// isLocalPath determines if the path is a local path
-func isLocalPath(path string) bool {
-	return !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "git@")
+// shouldRunHooks returns whether post-create hooks run for this project
+func (pe *ProjectExecutor) shouldRunHooks(config *models.ProjectConfig) bool {
+	return pe.hooksEnabled && config.Manifest != nil && len(config.Manifest.Hooks.PostCreate) > 0
 }
 
-// copyFromLocalTemplate copies files from local template
-func (pe *ProjectExecutor) copyFromLocalTemplate(sourcePath, targetPath string) error {
-	// Create target directory
-	if err := os.MkdirAll(targetPath, 0755); err != nil {
-		return models.NewProjectError("failed to create target directory", err)
+// runPostCreateHooks runs post-create hooks and removes the project if a required hook fails
+func (pe *ProjectExecutor) runPostCreateHooks(ctx context.Context, config *models.ProjectConfig) error {
+	if !pe.shouldRunHooks(config) {
+		return nil
 	}
 
-	// Copy files from source directory to target directory
-	if err := copyDir(sourcePath, targetPath); err != nil {
-		return models.NewProjectError("failed to copy template files", err)
+	targetPath := config.GetLocalCreatePath()
+	err := NewHookRunner(targetPath).RunPostCreate(ctx, config.Manifest.Hooks.PostCreate)
+	if err != nil {
+		if removeErr := os.RemoveAll(targetPath); removeErr != nil {
+			fmt.Printf("⚠️  Failed to remove project directory: %v\n", removeErr)
+		}
+		return err
 	}
 
-	// Initialize as Git repository
-	return pe.initializeGitRepository(context.Background(), targetPath)
+	return nil
 }
 
-// copyDir recursively copies directories
-func copyDir(src, dst string) error {
-	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
+// createLocalDirectory creates the project directory from the template (or with basic files)
+// and initializes it as a git repository
+func (pe *ProjectExecutor) createLocalDirectory(ctx context.Context, config *models.ProjectConfig) error {
+	targetPath := config.GetLocalCreatePath()
+
+	// Check if directory already exists
+	if _, err := os.Stat(targetPath); !os.IsNotExist(err) {
+		return models.NewProjectError(
+			fmt.Sprintf("directory '%s' already exists", targetPath),
+			nil,
+		)
+	}
+
+	if err := os.MkdirAll(targetPath, 0755); err != nil {
+		return models.NewValidationError(fmt.Sprintf("Failed to create directory: %v", err))
+	}
+
+	if config.HasTemplate() {
+		// Check for context cancellation
+		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		// Calculate relative path
-		relPath, err := filepath.Rel(src, path)
-		if err != nil {
+		fmt.Printf("📦 Applying template '%s'...\n", config.Template.GetReference())
+		if err := pe.copyTemplateFiles(ctx, config); err != nil {
 			return err
 		}
+	} else if err := createBasicFiles(config); err != nil {
+		return err
+	}
 
-		dstPath := filepath.Join(dst, relPath)
-
-		if info.IsDir() {
-			// Create directory if it's a directory
-			return os.MkdirAll(dstPath, info.Mode())
-		} else {
-			// Copy file if it's a file
-			return copyFile(path, dstPath)
-		}
-	})
+	return utils.NewGitService(targetPath).InitializeRepository(ctx)
 }
 
-// copyFile copies a file
-func copyFile(src, dst string) error {
-	srcFile, err := os.Open(src)
+// copyTemplateFiles copies the template into the project directory, rendering paths and contents,
+// and records the template commit so the project can be updated later
+func (pe *ProjectExecutor) copyTemplateFiles(ctx context.Context, config *models.ProjectConfig) error {
+	checkoutDir, cleanup, err := checkoutTemplate(ctx, config.Template)
 	if err != nil {
 		return err
 	}
-	defer srcFile.Close()
+	defer cleanup()
 
-	// Create directory if it doesn't exist
-	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+	// Only the template's subdirectory is copied from a collection repository
+	sourceDir, err := TemplateSourceDir(checkoutDir, config.Template)
+	if err != nil {
 		return err
 	}
 
-	dstFile, err := os.Create(dst)
-	if err != nil {
+	// Copy files excluding .git directory and the manifest (paths and contents are rendered for manifest templates)
+	targetPath := config.GetLocalCreatePath()
+	if err := NewTemplateRenderer(config).CopyTree(sourceDir, targetPath, []string{".git", models.ManifestFileName}); err != nil {
 		return err
 	}
-	defer dstFile.Close()
 
-	_, err = srcFile.WriteTo(dstFile)
-	return err
-}
-
-// initializeGitRepository initializes Git repository
-func (pe *ProjectExecutor) initializeGitRepository(ctx context.Context, targetPath string) error {
-	gitService := utils.NewGitService(targetPath)
+	// Templates without a manifest only get legacy placeholder replacement
+	if config.Manifest == nil {
+		if err := applyLegacyPlaceholders(config, targetPath); err != nil {
+			// Continue template application even if error occurs
+			fmt.Printf("⚠️  Failed to update template variables: %v\n", err)
+		}
+	}
 
-	// Initialize Git
-	if err := gitService.InitializeRepository(ctx); err != nil {
-		return models.NewProjectError("failed to initialize Git repository", err)
+	// A local template's HEAD only describes the copied files when the template is a clean repository of its own
+	if config.Template.IsLocal() {
+		if reason := unversionedLocalTemplate(ctx, checkoutDir); reason != "" {
+			fmt.Printf("⚠️  Skipping %s: %s, so the project cannot be updated from the template\n", models.LockFileName, reason)
+			return nil
+		}
 	}
 
-	// Add all files
-	if err := gitService.AddAllFiles(ctx); err != nil {
-		return models.NewProjectError("failed to add files", err)
+	// Record the template commit and answers so the project can be updated later
+	commit, err := ResolveCommit(ctx, sourceDir, "HEAD")
+	if err != nil {
+		return err
 	}
+	return WriteProjectLock(targetPath, models.NewProjectLock(config, commit))
+}
 
-	// Create initial commit
-	if err := gitService.CreateInitialCommit(ctx, "Initial commit"); err != nil {
-		return models.NewProjectError("failed to create initial commit", err)
+// unversionedLocalTemplate returns why the commit of the local template at dir does not describe its files,
+// or "" when it does. A directory inside another repository would otherwise record that repository's HEAD.
+func unversionedLocalTemplate(ctx context.Context, dir string) string {
+	backend := utils.DefaultGitBackend()
+
+	topLevel, err := backend.TopLevel(ctx, dir)
+	if err != nil || !samePath(topLevel, dir) {
+		return fmt.Sprintf("template '%s' is not the top level of a git repository", dir)
 	}
 
-	return nil
+	dirty, err := backend.HasUncommittedChanges(ctx, dir)
+	if err != nil || dirty {
+		return fmt.Sprintf("template '%s' has uncommitted changes", dir)
+	}
+	return ""
 }
 
-// createGitHubRepository creates a GitHub repository
-func (pe *ProjectExecutor) createGitHubRepository(ctx context.Context, config *models.ProjectConfig) error {
-	// Create repository using GitHub CLI
-	cmd := exec.CommandContext(ctx, "gh", "repo", "create", config.Name)
+// samePath reports whether two paths name the same directory, resolving symbolic links
+func samePath(a, b string) bool {
+	resolvedA, errA := filepath.EvalSymlinks(a)
+	resolvedB, errB := filepath.EvalSymlinks(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	absA, errA := filepath.Abs(resolvedA)
+	absB, errB := filepath.Abs(resolvedB)
+	return errA == nil && errB == nil && absA == absB
+}
 
-	if config.IsPrivate {
-		cmd.Args = append(cmd.Args, "--private")
-	} else {
-		cmd.Args = append(cmd.Args, "--public")
+// checkoutTemplate returns the directory holding the template's repository. Local templates are used
+// in place, as they are, including uncommitted changes; others are cloned into a temporary directory
+// that cleanup removes.
+func checkoutTemplate(ctx context.Context, template *models.Template) (string, func(), error) {
+	if template.IsLocal() {
+		return template.CloneURL, func() {}, nil
 	}
 
-	if config.Description != "" {
-		cmd.Args = append(cmd.Args, "--description", config.Description)
+	tempDir, err := os.MkdirTemp("", "gh-wizard-template-*")
+	if err != nil {
+		return "", nil, models.NewValidationError(fmt.Sprintf("Failed to create temporary directory: %v", err))
 	}
+	cleanup := func() { os.RemoveAll(tempDir) }
 
-	if err := cmd.Run(); err != nil {
-		return models.NewProjectError("failed to create GitHub repository", err)
+	if err := CloneTemplate(ctx, template, tempDir); err != nil {
+		cleanup()
+		return "", nil, err
 	}
+	return tempDir, cleanup, nil
+}
 
+// createBasicFiles creates the README of a project without a template
+func createBasicFiles(config *models.ProjectConfig) error {
+	if err := writeBasicReadme(config, config.GetLocalCreatePath()); err != nil {
+		return models.NewValidationError(fmt.Sprintf("Failed to create README.md: %v", err))
+	}
 	return nil
 }
 
-// pushToGitHub pushes local repository to GitHub
-func (pe *ProjectExecutor) pushToGitHub(ctx context.Context, config *models.ProjectConfig) error {
-	targetPath := config.GetLocalCreatePath()
-	gitService := utils.NewGitService(targetPath)
+// printSuccess displays success message (repoInfo is nil when no GitHub repository was created)
+func (pe *ProjectExecutor) printSuccess(config *models.ProjectConfig, repoInfo *github.RepositoryInfo) {
+	fmt.Println()
+	fmt.Println("✨ Project successfully created!")
+	fmt.Println()
 
-	// Add remote repository
-	repoURL := fmt.Sprintf("https://github.com/%s/%s.git", getCurrentUser(), config.Name)
-	if err := gitService.AddRemote(ctx, "origin", repoURL); err != nil {
-		return models.NewProjectError("failed to add remote repository", err)
+	// Display project information
+	summary := config.GetDisplaySummary()
+	for _, line := range summary {
+		fmt.Printf("  %s\n", line)
 	}
 
-	// Get current branch
-	branch, err := gitService.GetCurrentBranch(ctx)
-	if err != nil {
-		// Use default main if branch cannot be retrieved
-		branch = "main"
+	fmt.Println()
+
+	// Suggest next steps
+	fmt.Println("📝 Next steps:")
+	fmt.Printf("  cd %s\n", config.GetLocalCreatePath())
+
+	if config.Manifest != nil && len(config.Manifest.Hooks.PostCreate) > 0 {
+		// Hooks declared by the template replace the language-based guess
+		if !pe.shouldRunHooks(config) {
+			for _, hook := range config.Manifest.Hooks.PostCreate {
+				fmt.Printf("  %s\n", hook.Run)
+			}
+		}
+	} else if config.Template != nil && config.Template.Language != "" {
+		switch config.Template.Language {
+		case "JavaScript", "TypeScript":
+			fmt.Println("  npm install")
+			fmt.Println("  npm run dev")
+		case "Go":
+			fmt.Println("  go mod tidy")
+			fmt.Println("  go run main.go")
+		case "Python":
+			fmt.Println("  pip install -r requirements.txt")
+			fmt.Println("  python main.py")
+		default:
+			fmt.Println("  # Please check the project README")
+		}
 	}
 
-	// Push
-	if err := gitService.PushToRemote(ctx, "origin", branch); err != nil {
-		return models.NewProjectError("failed to push to GitHub", err)
+	if repoInfo != nil {
+		fmt.Printf("\n🔗 GitHub repository: %s\n", repoInfo.HTMLURL)
 	}
+}
 
-	return nil
+// isLocalPath determines if the path is a local path
+func isLocalPath(path string) bool {
+	return !IsGitURL(path)
 }
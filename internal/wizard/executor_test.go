@@ -3,6 +3,7 @@ package wizard
 import (
 	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
@@ -84,6 +85,7 @@ func (suite *ExecutorTestSuite) TestCreateProjectWithTemplate() {
 		Template: &models.Template{
 			FullName: "test/template",
 			CloneURL: templateDir, // テスト用にローカルパス使用
+			Source:   models.SourceLocal,
 		},
 		CreateGitHub: false,
 	}
@@ -101,6 +103,39 @@ func (suite *ExecutorTestSuite) TestCreateProjectWithTemplate() {
 	}
 }
 
+func (suite *ExecutorTestSuite) TestCreateProjectWithManifest() {
+	templateDir := filepath.Join(suite.tempDir, "template")
+	writeTestFiles(suite.T(), templateDir, map[string]string{
+		"package.json":          `{"name": "{{.Name}}", "ci": {{.use_ci}}}`,
+		models.ManifestFileName: "variables:\n  - name: use_ci\n    type: bool\n",
+	})
+
+	projectPath := filepath.Join(suite.tempDir, "rendered")
+	manifest, err := LoadTemplateManifest(context.Background(), suite.mockClient, &models.Template{CloneURL: templateDir})
+	require.NoError(suite.T(), err)
+
+	config := &models.ProjectConfig{
+		Name:      "rendered",
+		LocalPath: projectPath,
+		Template: &models.Template{
+			FullName: "test/template",
+			CloneURL: templateDir,
+			Source:   models.SourceLocal,
+		},
+		Variables: map[string]interface{}{"use_ci": true},
+		Manifest:  manifest,
+	}
+
+	require.NoError(suite.T(), suite.executor.createLocalDirectory(context.Background(), config))
+
+	content, err := os.ReadFile(filepath.Join(projectPath, "package.json"))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `{"name": "rendered", "ci": true}`, string(content))
+
+	// マニフェストは生成物に含めない
+	assert.NoFileExists(suite.T(), filepath.Join(projectPath, models.ManifestFileName))
+}
+
 func (suite *ExecutorTestSuite) TestCreateProject_DirectoryExists() {
 	projectPath := filepath.Join(suite.tempDir, "existing-project")
 
@@ -143,3 +178,67 @@ func TestProjectExecutor_Execute_LocalOnly(t *testing.T) {
 	gitDir := filepath.Join(projectPath, ".git")
 	assert.DirExists(t, gitDir)
 }
+
+func TestProjectExecutor_Execute_WithGitHub(t *testing.T) {
+	tempDir := t.TempDir()
+	projectPath := filepath.Join(tempDir, "test-github-project")
+
+	// GitHub の代わりにローカルのベアリポジトリへプッシュする
+	remotePath := filepath.Join(tempDir, "remote.git")
+	require.NoError(t, exec.Command("git", "init", "--bare", remotePath).Run())
+
+	mockClient := github.NewSimpleMockClient()
+	mockClient.Repository = &github.RepositoryInfo{
+		Name:     "test-github-project",
+		FullName: "acme/test-github-project",
+		CloneURL: remotePath,
+		HTMLURL:  "https://github.com/acme/test-github-project",
+	}
+
+	config := &models.ProjectConfig{
+		Name:         "test-github-project",
+		Description:  "Test GitHub project",
+		LocalPath:    projectPath,
+		CreateGitHub: true,
+		Owner:        "acme",
+		Teams:        []models.TeamGrant{{Team: "backend", Permission: "push"}, {Team: "sre", Permission: "admin"}},
+		RepoSettings: &models.RepositorySettings{
+			MergeStrategies:  []string{"squash"},
+			BranchProtection: []models.BranchProtection{{Branch: "main", RequiredReviews: 1}},
+		},
+	}
+
+	err := NewProjectExecutor(mockClient).Execute(context.Background(), config)
+	require.NoError(t, err)
+
+	// クライアント経由でリポジトリが作成されていること
+	require.Len(t, mockClient.Created, 1)
+	assert.Equal(t, "test-github-project", mockClient.Created[0].Name)
+
+	// 作成直後にチームへ権限が付与されていること
+	assert.Equal(t, []string{"acme/test-github-project backend:push", "acme/test-github-project sre:admin"}, mockClient.Granted)
+
+	// プッシュ後にリポジトリ設定が適用されていること
+	require.Len(t, mockClient.Applied, 2)
+	assert.Equal(t, "PUT repos/acme/test-github-project/branches/main/protection", mockClient.Applied[1].String())
+
+	// 初回コミットがリモートにプッシュされていること
+	output, err := exec.Command("git", "--git-dir", remotePath, "log", "--all", "--format=%s").Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "Initial commit")
+}
+
+func TestProjectExecutor_Execute_CreateRepositoryError(t *testing.T) {
+	mockClient := github.NewSimpleMockClient()
+	mockClient.CreateError = models.NewGitHubError("repository already exists", nil)
+
+	config := &models.ProjectConfig{
+		Name:         "test-project",
+		LocalPath:    filepath.Join(t.TempDir(), "test-project"),
+		CreateGitHub: true,
+	}
+
+	err := NewProjectExecutor(mockClient).Execute(context.Background(), config)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository already exists")
+}
@@ -0,0 +1,75 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/utils"
+)
+
+// generatedCommitMessage is the message of the commit applying template variables to a generated repository
+const generatedCommitMessage = "Apply template variables"
+
+// GenerateProject creates the project's repository from its template on GitHub and clones it into the
+// project directory. Template paths and contents are rendered in the clone; PushGeneratedProject publishes them.
+func GenerateProject(ctx context.Context, client github.Client, config *models.ProjectConfig) (*github.RepositoryInfo, error) {
+	targetPath := config.GetLocalCreatePath()
+	if _, err := os.Stat(targetPath); !os.IsNotExist(err) {
+		return nil, models.NewProjectError(fmt.Sprintf("directory '%s' already exists", targetPath), nil)
+	}
+
+	repoInfo, err := client.GenerateRepository(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.DefaultGitBackend().Clone(ctx, repoInfo.CloneURL, targetPath); err != nil {
+		return nil, models.NewGitHubError(fmt.Sprintf("Failed to clone generated repository '%s'", repoInfo.FullName), err)
+	}
+
+	if err := NewTemplateRenderer(config).RenderTree(targetPath); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(filepath.Join(targetPath, models.ManifestFileName)); err != nil && !os.IsNotExist(err) {
+		return nil, models.NewProjectError("failed to remove template manifest", err)
+	}
+
+	if repoInfo.TemplateCommit != "" {
+		if err := WriteProjectLock(targetPath, models.NewProjectLock(config, repoInfo.TemplateCommit)); err != nil {
+			return nil, err
+		}
+	}
+
+	return repoInfo, nil
+}
+
+// PushGeneratedProject commits what rendering and hooks changed in a generated project and pushes it
+func PushGeneratedProject(ctx context.Context, config *models.ProjectConfig) error {
+	gitService := utils.NewGitService(config.GetLocalCreatePath())
+
+	dirty, err := gitService.HasUncommittedChanges(ctx)
+	if err != nil || !dirty {
+		return err
+	}
+
+	if err := gitService.AddAllFiles(ctx); err != nil {
+		return models.NewProjectError("failed to add files", err)
+	}
+	if err := gitService.CreateInitialCommit(ctx, generatedCommitMessage); err != nil {
+		return models.NewProjectError("failed to commit template variables", err)
+	}
+
+	branch, err := gitService.GetCurrentBranch(ctx)
+	if err != nil {
+		return err
+	}
+	if err := gitService.PushToRemote(ctx, "origin", branch); err != nil {
+		return models.NewGitHubError("Failed to push template variables", err)
+	}
+
+	return nil
+}
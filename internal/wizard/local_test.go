@@ -0,0 +1,103 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsLocalTemplateRef(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected bool
+	}{
+		{"./templates/service", true},
+		{"../service", true},
+		{"/opt/templates/service", true},
+		{"~/templates/service", true},
+		{"file:///opt/templates/service", true},
+		{"acme/service", false},
+		{"service", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsLocalTemplateRef(tt.ref))
+		})
+	}
+}
+
+func TestLocalTemplate(t *testing.T) {
+	templateDir := filepath.Join(t.TempDir(), "service-skeleton")
+	require.NoError(t, os.Mkdir(templateDir, 0755))
+
+	// パスからローカルテンプレートを作る
+	template, err := LocalTemplate(templateDir)
+	require.NoError(t, err)
+	assert.Equal(t, "service-skeleton", template.Name)
+	assert.Equal(t, templateDir, template.CloneURL)
+	assert.Equal(t, models.SourceLocal, template.Source)
+	assert.True(t, template.IsTemplate)
+	assert.Empty(t, template.FullName)
+
+	// file:// URL も受け付ける
+	template, err = LocalTemplate("file://" + filepath.ToSlash(templateDir))
+	require.NoError(t, err)
+	assert.Equal(t, templateDir, template.CloneURL)
+
+	// 相対パスは絶対パスにする
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Dir(templateDir)))
+	defer os.Chdir(wd)
+	template, err = LocalTemplate("./service-skeleton")
+	require.NoError(t, err)
+	assert.True(t, filepath.IsAbs(template.CloneURL))
+	assert.Equal(t, "service-skeleton", filepath.Base(template.CloneURL))
+}
+
+func TestLocalTemplate_Errors(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "file.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("x"), 0644))
+
+	_, err := LocalTemplate(filepath.Join(dir, "missing"))
+	assert.ErrorContains(t, err, "is not a directory")
+
+	_, err = LocalTemplate(filePath)
+	assert.ErrorContains(t, err, "is not a directory")
+
+	_, err = LocalTemplate("file://example.com/templates")
+	assert.ErrorContains(t, err, "must not name a host")
+}
+
+func TestLocalTemplates_Collection(t *testing.T) {
+	collectionDir := filepath.Join(t.TempDir(), "templates")
+	writeTestFiles(t, collectionDir, map[string]string{
+		models.ManifestFileName: "templates:\n  - name: go-service\n    path: services/go\n  - path: web\n",
+		"services/go/main.go":   "package main\n",
+		"web/index.html":        "<html></html>\n",
+	})
+
+	// マニフェストで宣言したサブテンプレートを個別に返す
+	templates, err := LocalTemplates(collectionDir)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "go-service", templates[0].Name)
+	assert.Equal(t, collectionDir, templates[0].CloneURL)
+	assert.Equal(t, "services/go", templates[0].Path)
+	assert.Equal(t, collectionDir+"//web", templates[1].GetReference())
+	assert.True(t, templates[1].IsLocal())
+
+	// コレクションでなければテンプレート自身を返す
+	templates, err = LocalTemplates(filepath.Join(collectionDir, "web"))
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "web", templates[0].Name)
+	assert.Empty(t, templates[0].Path)
+}
@@ -0,0 +1,43 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// ReadProjectLock reads the lockfile of a generated project
+func ReadProjectLock(projectDir string) (*models.ProjectLock, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, models.LockFileName))
+	if os.IsNotExist(err) {
+		return nil, models.NewValidationError(
+			fmt.Sprintf("No %s found in %s; was this project generated by gh-wizard?", models.LockFileName, projectDir),
+		)
+	}
+	if err != nil {
+		return nil, models.NewProjectError("failed to read lockfile", err)
+	}
+
+	lock, err := models.ParseProjectLock(data)
+	if err != nil {
+		return nil, models.NewValidationError(fmt.Sprintf("Invalid %s: %v", models.LockFileName, err))
+	}
+
+	return lock, nil
+}
+
+// WriteProjectLock writes the lockfile into a generated project
+func WriteProjectLock(projectDir string, lock *models.ProjectLock) error {
+	data, err := lock.Marshal()
+	if err != nil {
+		return models.NewProjectError("failed to encode lockfile", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(projectDir, models.LockFileName), data, 0644); err != nil {
+		return models.NewProjectError("failed to write lockfile", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// LoadTemplateManifest loads the manifest shipped by the template (nil if the template has none)
+func LoadTemplateManifest(ctx context.Context, client github.Client, template *models.Template) (*models.TemplateManifest, error) {
+	if template == nil {
+		return nil, nil
+	}
+
+	var data []byte
+	var err error
+
+	switch {
+	case template.GetSource() == models.SourceGit || template.Ref != "":
+		// Only a clone can read another host or a ref other than the default branch
+		data, err = readClonedManifest(ctx, template)
+	case template.CloneURL != "" && isLocalPath(template.CloneURL):
+		data, err = os.ReadFile(filepath.Join(template.CloneURL, filepath.FromSlash(manifestPath(template))))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+	default:
+		data, err = client.GetFileContent(ctx, template.FullName, manifestPath(template))
+	}
+
+	if err != nil {
+		return nil, models.NewGitHubError(
+			fmt.Sprintf("Failed to load manifest of template '%s'", template.GetReference()),
+			err,
+		)
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	manifest, err := models.ParseManifest(data)
+	if err != nil {
+		return nil, models.NewValidationError(
+			fmt.Sprintf("Invalid manifest in template '%s': %v", template.GetReference(), err),
+		)
+	}
+
+	if len(manifest.Templates) > 0 {
+		return nil, collectionError(template, manifest)
+	}
+
+	return manifest, nil
+}
+
+// manifestPath returns the slash-separated path of the template's manifest within its repository
+func manifestPath(template *models.Template) string {
+	return path.Join(template.Path, models.ManifestFileName)
+}
+
+// collectionError explains that a collection repository is not a template itself and lists its templates
+func collectionError(template *models.Template, manifest *models.TemplateManifest) error {
+	references := make([]string, 0, len(manifest.Templates))
+	for _, subTemplate := range template.SubTemplates(manifest.Templates) {
+		references = append(references, subTemplate.GetReference())
+	}
+
+	return models.NewValidationError(
+		fmt.Sprintf("'%s' is a collection of templates; choose one of: %s",
+			template.GetReference(), strings.Join(references, ", ")),
+	)
+}
+
+// readClonedManifest clones the template at its ref and reads the manifest (nil if the template has none)
+func readClonedManifest(ctx context.Context, template *models.Template) ([]byte, error) {
+	tempDir, err := os.MkdirTemp("", "gh-wizard-manifest-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := CloneTemplate(ctx, template, tempDir); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, filepath.FromSlash(manifestPath(template))))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
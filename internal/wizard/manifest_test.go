@@ -0,0 +1,81 @@
+package wizard
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTemplateManifest(t *testing.T) {
+	manifestYAML := []byte("variables:\n  - name: use_ci\n    type: bool\n")
+
+	t.Run("remote template", func(t *testing.T) {
+		client := github.NewSimpleMockClient()
+		client.Files = map[string][]byte{
+			"testuser/nextjs-starter/" + models.ManifestFileName: manifestYAML,
+		}
+
+		manifest, err := LoadTemplateManifest(context.Background(), client, &models.Template{FullName: "testuser/nextjs-starter"})
+		require.NoError(t, err)
+		require.NotNil(t, manifest)
+		assert.Equal(t, "use_ci", manifest.Variables[0].Name)
+	})
+
+	t.Run("local template", func(t *testing.T) {
+		templateDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(templateDir, models.ManifestFileName), manifestYAML, 0644))
+
+		manifest, err := LoadTemplateManifest(context.Background(), github.NewSimpleMockClient(), &models.Template{CloneURL: templateDir})
+		require.NoError(t, err)
+		require.NotNil(t, manifest)
+		assert.Len(t, manifest.Variables, 1)
+	})
+
+	t.Run("template without manifest", func(t *testing.T) {
+		manifest, err := LoadTemplateManifest(context.Background(), github.NewSimpleMockClient(), &models.Template{FullName: "testuser/go-cli-template"})
+		require.NoError(t, err)
+		assert.Nil(t, manifest)
+	})
+
+	t.Run("subdirectory template", func(t *testing.T) {
+		client := github.NewSimpleMockClient()
+		client.Files = map[string][]byte{
+			"acme/templates/services/go/" + models.ManifestFileName: manifestYAML,
+		}
+
+		template := &models.Template{FullName: "acme/templates", Path: "services/go"}
+		manifest, err := LoadTemplateManifest(context.Background(), client, template)
+		require.NoError(t, err)
+		require.NotNil(t, manifest)
+		assert.Len(t, manifest.Variables, 1)
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		client := github.NewSimpleMockClient()
+		client.Files = map[string][]byte{
+			"acme/templates/" + models.ManifestFileName: []byte("templates:\n  - path: services/go\n  - path: web\n"),
+		}
+
+		// コレクション自体はテンプレートとして使えない
+		_, err := LoadTemplateManifest(context.Background(), client, &models.Template{FullName: "acme/templates"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "acme/templates//services/go, acme/templates//web")
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		client := github.NewSimpleMockClient()
+		client.Files = map[string][]byte{
+			"testuser/nextjs-starter/" + models.ManifestFileName: []byte("variables:\n  - name: bad-name\n"),
+		}
+
+		_, err := LoadTemplateManifest(context.Background(), client, &models.Template{FullName: "testuser/nextjs-starter"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid manifest")
+	})
+}
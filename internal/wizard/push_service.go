@@ -11,23 +11,16 @@ import (
 
 // PushService manages pushes to GitHub
 type PushService struct {
-	repoService *github.RepositoryService
-	gitService  *utils.GitService
+	githubClient github.Client
+	gitService   *utils.GitService
 }
 
 // NewPushService creates a new push service
-func NewPushService(projectPath string) (*PushService, error) {
-	repoService, err := github.NewRepositoryService()
-	if err != nil {
-		return nil, err
-	}
-
-	gitService := utils.NewGitService(projectPath)
-
+func NewPushService(projectPath string, githubClient github.Client) *PushService {
 	return &PushService{
-		repoService: repoService,
-		gitService:  gitService,
-	}, nil
+		githubClient: githubClient,
+		gitService:   utils.NewGitService(projectPath),
+	}
 }
 
 // PushToGitHub pushes local repository to GitHub
@@ -37,15 +30,11 @@ func (ps *PushService) PushToGitHub(ctx context.Context, config *models.ProjectC
 	}
 
 	// Create GitHub repository
-	repoInfo, err := ps.repoService.CreateRepository(ctx, config)
+	repoInfo, err := ps.githubClient.CreateRepository(ctx, config)
 	if err != nil {
 		return err
 	}
 
-	if repoInfo == nil {
-		return nil // Repository creation skipped
-	}
-
 	// Initialize Git and commit
 	if err := ps.initializeLocalRepository(ctx, config); err != nil {
 		return err
@@ -77,7 +66,7 @@ func (ps *PushService) initializeLocalRepository(ctx context.Context, config *mo
 	// Create initial commit
 	commitMessage := fmt.Sprintf("Initial commit for %s", config.Name)
 	if config.HasTemplate() {
-		commitMessage = fmt.Sprintf("Initial commit from template %s", config.Template.FullName)
+		commitMessage = fmt.Sprintf("Initial commit from template %s", config.Template.GetReference())
 	}
 
 	if err := ps.gitService.CreateInitialCommit(ctx, commitMessage); err != nil {
@@ -3,8 +3,12 @@ package wizard
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
 	"github.com/mattn/go-runewidth"
 	"golang.org/x/term"
@@ -16,7 +20,10 @@ type Answers struct {
 	ProjectName  string `survey:"projectName"`
 	Description  string `survey:"description"`
 	CreateGitHub bool   `survey:"createGitHub"`
+	Owner        string `survey:"owner"`
 	IsPrivate    bool   `survey:"isPrivate"`
+	Topics       string `survey:"topics"`
+	Homepage     string `survey:"homepage"`
 }
 
 // SurveyExecutor interface for survey execution
@@ -31,11 +38,34 @@ func (d *DefaultSurveyExecutor) Ask(questions []*survey.Question, response inter
 	return survey.Ask(questions, response)
 }
 
+// changeSortOption is the extra template option that changes the sort order
+const changeSortOption = "↕ Change sort order"
+
+// Pre-filter options that keep every template
+const (
+	anyTopicOption    = "Any topic"
+	anyLanguageOption = "Any language"
+)
+
+// ManifestLoader loads the manifest of the selected template
+type ManifestLoader func(template *models.Template) (*models.TemplateManifest, error)
+
+// OwnerLoader lists the users and organizations a repository can be created under
+type OwnerLoader func() ([]string, error)
+
 // QuestionFlow manages question flow
 type QuestionFlow struct {
 	templates      []models.Template
 	answers        *Answers
 	surveyExecutor SurveyExecutor
+	manifestLoader ManifestLoader
+	ownerLoader    OwnerLoader
+	owners         []string
+	defaultOwner   string
+	manifest       *models.TemplateManifest
+	variables      map[string]interface{}
+	sortOrder      models.TemplateSortOrder
+	filter         models.TemplateFilter
 }
 
 // NewQuestionFlow creates a new question flow
@@ -44,7 +74,202 @@ func NewQuestionFlow(templates []models.Template) *QuestionFlow {
 		templates:      templates,
 		answers:        &Answers{},
 		surveyExecutor: &DefaultSurveyExecutor{},
+		sortOrder:      models.SortByStars,
+	}
+}
+
+// SetSortOrder sets the order templates are listed in
+func (qf *QuestionFlow) SetSortOrder(order models.TemplateSortOrder) {
+	templates := make([]models.Template, len(qf.templates))
+	copy(templates, qf.templates)
+	github.SortTemplates(templates, order)
+	groupTemplatesByCategory(templates)
+
+	qf.templates = templates
+	qf.sortOrder = order
+}
+
+// groupTemplatesByCategory groups registry categories in name order, uncategorized templates last.
+// Recommended templates lead their group; otherwise the current order is kept.
+func groupTemplatesByCategory(templates []models.Template) {
+	sort.SliceStable(templates, func(i, j int) bool {
+		a, b := templates[i], templates[j]
+		if a.Category != b.Category {
+			if a.Category == "" || b.Category == "" {
+				return b.Category == ""
+			}
+			return strings.ToLower(a.Category) < strings.ToLower(b.Category)
+		}
+		return a.Recommended && !b.Recommended
+	})
+}
+
+// SetManifestLoader sets the loader used to fetch template-declared variables
+func (qf *QuestionFlow) SetManifestLoader(loader ManifestLoader) {
+	qf.manifestLoader = loader
+}
+
+// SetOwnerLoader sets the loader of the owners offered when creating a GitHub repository.
+// defaultOwner is preselected when it is one of them.
+func (qf *QuestionFlow) SetOwnerLoader(loader OwnerLoader, defaultOwner string) {
+	qf.ownerLoader = loader
+	qf.defaultOwner = defaultOwner
+}
+
+// loadOwners loads the repository owners to choose from (none when there is only one)
+func (qf *QuestionFlow) loadOwners() error {
+	qf.owners = nil
+	if qf.ownerLoader == nil {
+		return nil
+	}
+
+	owners, err := qf.ownerLoader()
+	if err != nil {
+		return fmt.Errorf("failed to list repository owners: %w", err)
+	}
+	if len(owners) > 1 {
+		qf.owners = owners
+	}
+	return nil
+}
+
+// createOwnerPrompt creates the prompt choosing the repository owner among the loaded owners
+func (qf *QuestionFlow) createOwnerPrompt() *survey.Select {
+	prompt := &survey.Select{
+		Message: "Repository owner:",
+		Options: qf.owners,
+		Help:    "Organizations are listed when you can create repositories in them",
+	}
+	for _, owner := range qf.owners {
+		if owner == qf.defaultOwner {
+			prompt.Default = owner
+		}
+	}
+	return prompt
+}
+
+// SetFilter sets the filter applied before template selection.
+// A non-empty filter replaces the interactive pre-filter questions.
+func (qf *QuestionFlow) SetFilter(filter models.TemplateFilter) {
+	qf.filter = filter
+}
+
+// filterValue is a topic or language offered by the pre-filter with its template count
+type filterValue struct {
+	Value string
+	Count int
+}
+
+// countFilterValues counts the values of the templates, most common first
+func countFilterValues(templates []models.Template, values func(models.Template) []string) []filterValue {
+	counts := make(map[string]int)
+	for _, template := range templates {
+		for _, value := range values(template) {
+			counts[value]++
+		}
+	}
+
+	result := make([]filterValue, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, filterValue{Value: value, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
+
+// askFilterValue asks for one pre-filter value; an empty result keeps every template
+func (qf *QuestionFlow) askFilterValue(name, message, anyOption string, values []filterValue, showCompleted bool) (string, error) {
+	options := []string{anyOption}
+	for _, value := range values {
+		options = append(options, fmt.Sprintf("%s (%d)", value.Value, value.Count))
+	}
+
+	answer := anyOption
+	questions := []*survey.Question{{
+		Name: name,
+		Prompt: &survey.Select{
+			Message: message,
+			Options: options,
+			Default: anyOption,
+		},
+	}}
+	if err := qf.surveyExecutor.Ask(questions, &answer); err != nil {
+		return "", err
+	}
+
+	if showCompleted {
+		clearPreviousLines(1)
+		fmt.Printf("✓ %s … %s\n", message, answer)
+	}
+
+	for i, option := range options[1:] {
+		if option == answer {
+			return values[i].Value, nil
+		}
 	}
+	return "", nil
+}
+
+// askTemplateFilter asks for a topic and a language to narrow the templates
+func (qf *QuestionFlow) askTemplateFilter(showCompleted bool) error {
+	topics := countFilterValues(qf.templates, func(template models.Template) []string {
+		return template.Topics
+	})
+	if len(topics) > 0 {
+		topic, err := qf.askFilterValue("topic", "Filter templates by topic:", anyTopicOption, topics, showCompleted)
+		if err != nil {
+			return err
+		}
+		if topic != "" {
+			qf.filter.Topics = []string{topic}
+		}
+	}
+
+	// Only offer the languages left after the topic filter
+	languages := countFilterValues(qf.filter.Apply(qf.templates), func(template models.Template) []string {
+		if template.Language == "" {
+			return nil
+		}
+		return []string{template.Language}
+	})
+	if len(languages) > 1 {
+		language, err := qf.askFilterValue("language", "Filter templates by language:", anyLanguageOption, languages, showCompleted)
+		if err != nil {
+			return err
+		}
+		qf.filter.Language = language
+	}
+
+	return nil
+}
+
+// filterTemplates narrows the templates before selection, asking for a filter when none was set
+func (qf *QuestionFlow) filterTemplates(showCompleted bool) error {
+	if len(qf.templates) == 0 {
+		return nil
+	}
+
+	if qf.filter.IsEmpty() {
+		if err := qf.askTemplateFilter(showCompleted); err != nil {
+			return fmt.Errorf("failed to filter templates: %w", err)
+		}
+		if qf.filter.IsEmpty() {
+			return nil
+		}
+	}
+
+	templates := qf.filter.Apply(qf.templates)
+	if len(templates) == 0 {
+		return models.NewValidationError(fmt.Sprintf("No templates match %s", qf.filter))
+	}
+	qf.templates = templates
+
+	return nil
 }
 
 // formatTemplateOption creates template option display format
@@ -59,7 +284,59 @@ func formatTemplateOption(template models.Template) string {
 		language = fmt.Sprintf(" [%s]", template.Language)
 	}
 
-	return fmt.Sprintf("%s%s%s", template.Name, stars, language)
+	// Show the owner (and subdirectory) so same-named templates of different owners are distinguishable.
+	// A registry display name replaces it; the reference is then shown in the details.
+	name := template.Name
+	if template.Title != "" {
+		name = template.Title
+	} else if template.FullName != "" {
+		name = template.GetReference()
+	}
+
+	if template.Category != "" {
+		name = template.Category + " › " + name
+	}
+
+	// Mark templates that do not come from GitHub
+	source := ""
+	if template.GetSource() != models.SourceGitHub {
+		source = fmt.Sprintf(" (%s)", template.GetSource())
+	}
+
+	recommended := ""
+	if template.Recommended {
+		recommended = " ★ recommended"
+	}
+
+	return fmt.Sprintf("%s%s%s%s%s", name, stars, language, source, recommended)
+}
+
+// formatTemplateDetails creates the description line of a template option with its topics and last update
+func formatTemplateDetails(template models.Template) string {
+	var parts []string
+	if template.Title != "" {
+		parts = append(parts, template.GetReference())
+	}
+	if template.Ref != "" {
+		parts = append(parts, "pinned to "+template.Ref)
+	}
+	if template.Description != "" {
+		parts = append(parts, template.Description)
+	}
+
+	if len(template.Topics) > 0 {
+		topics := make([]string, len(template.Topics))
+		for i, topic := range template.Topics {
+			topics[i] = "#" + topic
+		}
+		parts = append(parts, strings.Join(topics, " "))
+	}
+
+	if !template.UpdatedAt.IsZero() {
+		parts = append(parts, "updated "+template.UpdatedAt.Format("2006-01-02"))
+	}
+
+	return strings.Join(parts, " · ")
 }
 
 // getTerminalWidth gets the current terminal width
@@ -309,7 +586,11 @@ func (qf *QuestionFlow) GetProjectConfig() *models.ProjectConfig {
 		Template:     template,
 		CreateGitHub: qf.answers.CreateGitHub,
 		IsPrivate:    qf.answers.IsPrivate,
+		Owner:        qf.answers.Owner,
+		Repository:   qf.repositoryOptions(),
 		LocalPath:    "./" + qf.answers.ProjectName,
+		Variables:    qf.variables,
+		Manifest:     qf.manifest,
 	}
 }
 
@@ -333,7 +614,7 @@ func (qf *QuestionFlow) CreateQuestions() []*survey.Question {
 				Message: "Please select a template:",
 				Options: templateOptions,
 				Description: func(value string, index int) string {
-					return formatDescriptionForTerminalWithTemplates(qf.templates[index].Description, qf.templates)
+					return formatDescriptionForTerminalWithTemplates(formatTemplateDetails(qf.templates[index]), qf.templates)
 				},
 			},
 			Validate: survey.Required,
@@ -343,12 +624,44 @@ func (qf *QuestionFlow) CreateQuestions() []*survey.Question {
 	return questions
 }
 
+// askSortOrder asks for a new template order and re-sorts the templates
+func (qf *QuestionFlow) askSortOrder() error {
+	labels := make([]string, len(models.TemplateSortOrders))
+	for i, order := range models.TemplateSortOrders {
+		labels[i] = order.GetLabel()
+	}
+
+	var answer string
+	prompt := &survey.Select{
+		Message: "Sort templates by:",
+		Options: labels,
+		Default: qf.sortOrder.GetLabel(),
+	}
+	if err := survey.AskOne(prompt, &answer); err != nil {
+		return err
+	}
+
+	for _, order := range models.TemplateSortOrders {
+		if order.GetLabel() == answer {
+			qf.SetSortOrder(order)
+			break
+		}
+	}
+	return nil
+}
+
 // CreateConditionalQuestions generates conditional questions
 func (qf *QuestionFlow) CreateConditionalQuestions() []*survey.Question {
 	var questions []*survey.Question
 
 	// Questions displayed only when creating GitHub repository
 	if qf.answers.CreateGitHub {
+		if len(qf.owners) > 0 {
+			questions = append(questions, &survey.Question{
+				Name:   "owner",
+				Prompt: qf.createOwnerPrompt(),
+			})
+		}
 		questions = append(questions, &survey.Question{
 			Name: "isPrivate",
 			Prompt: &survey.Confirm{
@@ -357,11 +670,62 @@ func (qf *QuestionFlow) CreateConditionalQuestions() []*survey.Question {
 				Help:    "Private: Only you can access / Public: Anyone can access",
 			},
 		})
+		questions = append(questions,
+			&survey.Question{Name: "topics", Prompt: createTopicsPrompt(), Validate: validateTopics},
+			&survey.Question{Name: "homepage", Prompt: createHomepagePrompt(), Validate: validateHomepage},
+		)
 	}
 
 	return questions
 }
 
+// createTopicsPrompt creates the optional repository topics question
+func createTopicsPrompt() *survey.Input {
+	return &survey.Input{
+		Message: "Enter repository topics (optional):",
+		Help:    "Comma or space separated, e.g. go, cli",
+	}
+}
+
+// createHomepagePrompt creates the optional repository homepage question
+func createHomepagePrompt() *survey.Input {
+	return &survey.Input{
+		Message: "Enter repository homepage URL (optional):",
+		Help:    "Website shown on the repository page",
+	}
+}
+
+// validateTopics validates the topics answer
+func validateTopics(answer interface{}) error {
+	options := models.RepositoryOptions{Topics: models.ParseTopics(fmt.Sprint(answer))}
+	return options.Validate()
+}
+
+// validateHomepage validates the homepage answer
+func validateHomepage(answer interface{}) error {
+	options := models.RepositoryOptions{Homepage: strings.TrimSpace(fmt.Sprint(answer))}
+	return options.Validate()
+}
+
+// optionalAnswer formats an optional answer for display
+func optionalAnswer(answer string) string {
+	if strings.TrimSpace(answer) == "" {
+		return "(skipped)"
+	}
+	return answer
+}
+
+// repositoryOptions returns the repository options answered
+func (qf *QuestionFlow) repositoryOptions() models.RepositoryOptions {
+	if !qf.answers.CreateGitHub {
+		return models.RepositoryOptions{}
+	}
+	return models.RepositoryOptions{
+		Topics:   models.ParseTopics(qf.answers.Topics),
+		Homepage: strings.TrimSpace(qf.answers.Homepage),
+	}
+}
+
 // CreateBasicQuestions creates questions about project basic information
 func (qf *QuestionFlow) CreateBasicQuestions() []*survey.Question {
 	return []*survey.Question{
@@ -391,32 +755,215 @@ func (qf *QuestionFlow) CreateBasicQuestions() []*survey.Question {
 	}
 }
 
-// ExecuteCreateNextAppStyle runs the question flow with create-next-app style UI
-func (qf *QuestionFlow) ExecuteCreateNextAppStyle() (*models.ProjectConfig, error) {
-	fmt.Println()
+// CreateVariableQuestion creates a question for a template-declared variable
+func (qf *QuestionFlow) CreateVariableQuestion(variable models.TemplateVariable) *survey.Question {
+	question := &survey.Question{Name: variable.Name}
 
-	// 1. Template selection (if templates are available)
-	if len(qf.templates) > 0 {
-		templateQuestion := qf.CreateQuestions()[0]
+	switch variable.Type {
+	case models.VariableTypeBool:
+		question.Prompt = &survey.Confirm{
+			Message: variable.GetPrompt(),
+			Default: variable.DefaultValue().(bool),
+			Help:    variable.Help,
+		}
+	case models.VariableTypeChoice:
+		prompt := &survey.Select{
+			Message: variable.GetPrompt(),
+			Options: variable.Choices,
+			Help:    variable.Help,
+		}
+		if defaultValue := variable.DefaultValue().(string); defaultValue != "" {
+			prompt.Default = defaultValue
+		}
+		question.Prompt = prompt
+	case models.VariableTypeMultiChoice:
+		question.Prompt = &survey.MultiSelect{
+			Message: variable.GetPrompt(),
+			Options: variable.Choices,
+			Default: variable.DefaultValue().([]string),
+			Help:    variable.Help,
+		}
+		question.Validate = func(ans interface{}) error {
+			return variable.ValidateValue(optionAnswersToStrings(ans))
+		}
+	case models.VariableTypeInt:
+		question.Prompt = &survey.Input{
+			Message: variable.GetPrompt(),
+			Default: fmt.Sprint(variable.DefaultValue()),
+			Help:    variable.Help,
+		}
+		question.Validate = func(ans interface{}) error {
+			value, err := variable.ParseValue(fmt.Sprint(ans))
+			if err != nil {
+				return err
+			}
+			return variable.ValidateValue(value)
+		}
+	default:
+		question.Prompt = &survey.Input{
+			Message: variable.GetPrompt(),
+			Default: variable.DefaultValue().(string),
+			Help:    variable.Help,
+		}
+		question.Validate = func(ans interface{}) error {
+			return variable.ValidateValue(fmt.Sprint(ans))
+		}
+	}
+
+	return question
+}
+
+// optionAnswersToStrings converts MultiSelect answers to a string slice
+func optionAnswersToStrings(ans interface{}) []string {
+	values := []string{}
+	if options, ok := ans.([]core.OptionAnswer); ok {
+		for _, option := range options {
+			values = append(values, option.Value)
+		}
+	}
+	return values
+}
 
-		var templateAnswer struct {
-			Template string `survey:"template"`
+// askVariable asks a single template variable and returns its typed value
+func (qf *QuestionFlow) askVariable(variable models.TemplateVariable) (interface{}, error) {
+	question := qf.CreateVariableQuestion(variable)
+	questions := []*survey.Question{question}
+
+	switch variable.Type {
+	case models.VariableTypeBool:
+		value := variable.DefaultValue().(bool)
+		err := qf.surveyExecutor.Ask(questions, &value)
+		return value, err
+	case models.VariableTypeMultiChoice:
+		value := variable.DefaultValue().([]string)
+		err := qf.surveyExecutor.Ask(questions, &value)
+		return value, err
+	case models.VariableTypeInt:
+		value := fmt.Sprint(variable.DefaultValue())
+		if err := qf.surveyExecutor.Ask(questions, &value); err != nil {
+			return nil, err
 		}
+		return variable.ParseValue(value)
+	default:
+		value := variable.DefaultValue().(string)
+		err := qf.surveyExecutor.Ask(questions, &value)
+		return value, err
+	}
+}
 
-		err := survey.AskOne(templateQuestion.Prompt, &templateAnswer.Template, survey.WithValidator(templateQuestion.Validate))
+// askTemplateVariables asks the variables declared by the selected template's manifest
+func (qf *QuestionFlow) askTemplateVariables(showCompleted bool) error {
+	template := qf.findSelectedTemplate()
+	if template == nil || qf.manifestLoader == nil {
+		return nil
+	}
+
+	manifest, err := qf.manifestLoader(template)
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		return nil
+	}
+
+	qf.manifest = manifest
+	qf.variables = make(map[string]interface{}, len(manifest.Variables))
+
+	for _, variable := range manifest.Variables {
+		value, err := qf.askVariable(variable)
 		if err != nil {
-			return nil, fmt.Errorf("failed to execute template selection: %w", err)
+			return fmt.Errorf("failed to get variable '%s': %w", variable.Name, err)
+		}
+		if err := variable.ValidateValue(value); err != nil {
+			return fmt.Errorf("variable '%s': %w", variable.Name, err)
 		}
-		qf.answers.Template = templateAnswer.Template
+		qf.variables[variable.Name] = value
 
-		// Clear only the select prompt question line
-		clearPreviousLines(1)
+		if showCompleted {
+			clearPreviousLines(1)
+			fmt.Printf("✓ %s … %s\n", variable.GetPrompt(), formatVariableValue(value))
+		}
+	}
+
+	return nil
+}
+
+// formatVariableValue formats a variable value for display
+func formatVariableValue(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case []string:
+		if len(v) == 0 {
+			return "(none)"
+		}
+		return strings.Join(v, ", ")
+	case string:
+		if v == "" {
+			return "(skipped)"
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ExecuteCreateNextAppStyle runs the question flow with create-next-app style UI
+func (qf *QuestionFlow) ExecuteCreateNextAppStyle() (*models.ProjectConfig, error) {
+	fmt.Println()
+
+	// Narrow the templates by topic and language
+	if err := qf.filterTemplates(true); err != nil {
+		return nil, err
+	}
+
+	// 1. Template selection (if templates are available)
+	if len(qf.templates) > 0 {
+		for {
+			templateQuestion := qf.CreateQuestions()[0]
+
+			// Offer re-sorting as the last option
+			prompt := templateQuestion.Prompt.(*survey.Select)
+			prompt.Options = append(prompt.Options, changeSortOption)
+			describe := prompt.Description
+			prompt.Description = func(value string, index int) string {
+				if value == changeSortOption {
+					return "Sorted by: " + qf.sortOrder.GetLabel()
+				}
+				return describe(value, index)
+			}
+
+			var templateAnswer struct {
+				Template string `survey:"template"`
+			}
+
+			err := survey.AskOne(prompt, &templateAnswer.Template, survey.WithValidator(templateQuestion.Validate))
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute template selection: %w", err)
+			}
+
+			// Clear only the select prompt question line
+			clearPreviousLines(1)
+
+			if templateAnswer.Template != changeSortOption {
+				qf.answers.Template = templateAnswer.Template
+				break
+			}
+
+			if err := qf.askSortOrder(); err != nil {
+				return nil, fmt.Errorf("failed to change sort order: %w", err)
+			}
+			clearPreviousLines(1)
+		}
 
 		// Show completed template selection
 		selectedTemplate := qf.findSelectedTemplate()
 		templateName := "None"
 		if selectedTemplate != nil {
-			templateName = selectedTemplate.Name
+			templateName = selectedTemplate.GetTitle()
 		}
 		fmt.Printf("✓ Please select a template: … %s\n", templateName)
 	}
@@ -455,6 +1002,11 @@ func (qf *QuestionFlow) ExecuteCreateNextAppStyle() (*models.ProjectConfig, erro
 		fmt.Printf("✓ Enter project description (optional): … (skipped)\n")
 	}
 
+	// Template variables declared by the manifest
+	if err := qf.askTemplateVariables(true); err != nil {
+		return nil, err
+	}
+
 	// 4. GitHub repository creation
 	githubPrompt := &survey.Confirm{
 		Message: "Create repository on GitHub?",
@@ -475,7 +1027,24 @@ func (qf *QuestionFlow) ExecuteCreateNextAppStyle() (*models.ProjectConfig, erro
 	}
 	fmt.Printf("✓ Create repository on GitHub? … %s\n", githubAnswer)
 
-	// 5. Private repository (if creating GitHub repo)
+	// 5. Repository owner (if the user can create repositories in organizations)
+	if qf.answers.CreateGitHub {
+		if err := qf.loadOwners(); err != nil {
+			return nil, err
+		}
+	}
+	if qf.answers.CreateGitHub && len(qf.owners) > 0 {
+		err = survey.AskOne(qf.createOwnerPrompt(), &qf.answers.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository owner: %w", err)
+		}
+
+		// Clear the selection question line
+		clearPreviousLines(1)
+		fmt.Printf("✓ Repository owner: … %s\n", qf.answers.Owner)
+	}
+
+	// 6. Private repository (if creating GitHub repo)
 	if qf.answers.CreateGitHub {
 		privatePrompt := &survey.Confirm{
 			Message: "Create as private repository?",
@@ -495,6 +1064,21 @@ func (qf *QuestionFlow) ExecuteCreateNextAppStyle() (*models.ProjectConfig, erro
 			privateAnswer = "Private"
 		}
 		fmt.Printf("✓ Create as private repository? … %s\n", privateAnswer)
+
+		// 7. Topics and homepage (optional)
+		err = survey.AskOne(createTopicsPrompt(), &qf.answers.Topics, survey.WithValidator(validateTopics))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository topics: %w", err)
+		}
+		clearPreviousLines(1)
+		fmt.Printf("✓ Repository topics … %s\n", optionalAnswer(qf.answers.Topics))
+
+		err = survey.AskOne(createHomepagePrompt(), &qf.answers.Homepage, survey.WithValidator(validateHomepage))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository homepage: %w", err)
+		}
+		clearPreviousLines(1)
+		fmt.Printf("✓ Repository homepage … %s\n", optionalAnswer(qf.answers.Homepage))
 	}
 
 	fmt.Println()
@@ -505,6 +1089,10 @@ func (qf *QuestionFlow) ExecuteCreateNextAppStyle() (*models.ProjectConfig, erro
 		Description:  qf.answers.Description,
 		CreateGitHub: qf.answers.CreateGitHub,
 		IsPrivate:    qf.answers.IsPrivate,
+		Owner:        qf.answers.Owner,
+		Repository:   qf.repositoryOptions(),
+		Variables:    qf.variables,
+		Manifest:     qf.manifest,
 	}
 
 	// Search for template
@@ -520,6 +1108,11 @@ func (qf *QuestionFlow) ExecuteCreateNextAppStyle() (*models.ProjectConfig, erro
 
 // Execute runs the question flow and returns ProjectConfig
 func (qf *QuestionFlow) Execute() (*models.ProjectConfig, error) {
+	// Narrow the templates by topic and language
+	if err := qf.filterTemplates(false); err != nil {
+		return nil, err
+	}
+
 	// Execute template selection questions (only if templates are available)
 	questions := qf.CreateQuestions()
 	if len(questions) > 0 {
@@ -536,7 +1129,17 @@ func (qf *QuestionFlow) Execute() (*models.ProjectConfig, error) {
 		return nil, fmt.Errorf("failed to execute basic questions: %w", err)
 	}
 
+	// Template variables declared by the manifest
+	if err := qf.askTemplateVariables(false); err != nil {
+		return nil, err
+	}
+
 	// Execute conditional questions
+	if qf.answers.CreateGitHub {
+		if err := qf.loadOwners(); err != nil {
+			return nil, err
+		}
+	}
 	conditionalQuestions := qf.CreateConditionalQuestions()
 	if len(conditionalQuestions) > 0 {
 		err = qf.surveyExecutor.Ask(conditionalQuestions, qf.answers)
@@ -551,6 +1154,10 @@ func (qf *QuestionFlow) Execute() (*models.ProjectConfig, error) {
 		Description:  qf.answers.Description,
 		CreateGitHub: qf.answers.CreateGitHub,
 		IsPrivate:    qf.answers.IsPrivate,
+		Owner:        qf.answers.Owner,
+		Repository:   qf.repositoryOptions(),
+		Variables:    qf.variables,
+		Manifest:     qf.manifest,
 	}
 
 	// Search for template
@@ -1,8 +1,10 @@
 package wizard
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
@@ -30,7 +32,7 @@ func TestAnswers_ToProjectConfig(t *testing.T) {
 		{
 			name: "valid answers with template",
 			answers: Answers{
-				Template:     "test-template (⭐ 10) [Go]",
+				Template:     "user/test-template (⭐ 10) [Go]",
 				ProjectName:  "my-project",
 				Description:  "Test project",
 				CreateGitHub: true,
@@ -95,7 +97,7 @@ func TestQuestionFlow_CreateConditionalQuestions(t *testing.T) {
 		{
 			name:              "GitHub creation enabled",
 			createGitHub:      true,
-			expectedQuestions: 1, // IsPrivate question
+			expectedQuestions: 3, // IsPrivate, topics and homepage questions
 		},
 		{
 			name:              "GitHub creation disabled",
@@ -114,6 +116,8 @@ func TestQuestionFlow_CreateConditionalQuestions(t *testing.T) {
 			if tt.expectedQuestions > 0 {
 				// プライベートリポジトリの質問があることを確認
 				assert.Equal(t, "isPrivate", questions[0].Name)
+				assert.Equal(t, "topics", questions[1].Name)
+				assert.Equal(t, "homepage", questions[2].Name)
 			}
 		})
 	}
@@ -158,6 +162,45 @@ func TestFormatTemplateOption(t *testing.T) {
 			},
 			expected: "minimal",
 		},
+		{
+			name: "with owner",
+			template: models.Template{
+				Name:     "go-service",
+				FullName: "acme/go-service",
+				Stars:    3,
+			},
+			expected: "acme/go-service (⭐ 3)",
+		},
+		{
+			name: "local template",
+			template: models.Template{
+				Name:     "service-skeleton",
+				CloneURL: "/templates/service-skeleton",
+				Source:   models.SourceLocal,
+			},
+			expected: "service-skeleton (local)",
+		},
+		{
+			name: "subdirectory template",
+			template: models.Template{
+				Name:     "go-service",
+				FullName: "acme/templates",
+				Path:     "services/go",
+			},
+			expected: "acme/templates//services/go",
+		},
+		{
+			name: "registry template",
+			template: models.Template{
+				Name:        "go-service",
+				FullName:    "acme/go-service",
+				Title:       "Go service",
+				Category:    "Backend",
+				Recommended: true,
+				Stars:       3,
+			},
+			expected: "Backend › Go service (⭐ 3) ★ recommended",
+		},
 	}
 
 	for _, tt := range tests {
@@ -168,6 +211,130 @@ func TestFormatTemplateOption(t *testing.T) {
 	}
 }
 
+// TestFormatTemplateDetails はトピック付きの説明文をテスト
+func TestFormatTemplateDetails(t *testing.T) {
+	assert.Equal(t, "Go service", formatTemplateDetails(models.Template{Description: "Go service"}))
+	assert.Equal(t, "Go service · #go #grpc", formatTemplateDetails(models.Template{Description: "Go service", Topics: []string{"go", "grpc"}}))
+	assert.Equal(t, "#go", formatTemplateDetails(models.Template{Topics: []string{"go"}}))
+	assert.Equal(t, "", formatTemplateDetails(models.Template{}))
+	assert.Equal(t, "Go service · updated 2024-05-01", formatTemplateDetails(models.Template{
+		Description: "Go service",
+		UpdatedAt:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}))
+}
+
+// TestQuestionFlow_SetSortOrder は並び替えと選択肢の順序をテスト
+func TestQuestionFlow_SetSortOrder(t *testing.T) {
+	templates := []models.Template{
+		{Name: "beta", FullName: "me/beta", Stars: 5},
+		{Name: "alpha", FullName: "me/alpha", Stars: 1},
+	}
+
+	flow := NewQuestionFlow(templates)
+	flow.SetSortOrder(models.SortByName)
+
+	prompt := flow.CreateQuestions()[0].Prompt.(*survey.Select)
+	assert.Equal(t, []string{"me/alpha (⭐ 1)", "me/beta (⭐ 5)"}, prompt.Options)
+
+	// 呼び出し元のスライスは変更しない
+	assert.Equal(t, "beta", templates[0].Name)
+
+	flow.SetSortOrder(models.SortByStars)
+	prompt = flow.CreateQuestions()[0].Prompt.(*survey.Select)
+	assert.Equal(t, []string{"me/beta (⭐ 5)", "me/alpha (⭐ 1)"}, prompt.Options)
+}
+
+func TestQuestionFlow_GroupsByCategory(t *testing.T) {
+	templates := []models.Template{
+		{Name: "misc", FullName: "me/misc", Stars: 9},
+		{Name: "web", FullName: "acme/web", Stars: 8, Category: "Frontend", Recommended: true},
+		{Name: "api", FullName: "acme/api", Stars: 2, Category: "backend"},
+		{Name: "worker", FullName: "acme/worker", Stars: 1, Category: "backend", Recommended: true},
+	}
+
+	flow := NewQuestionFlow(templates)
+	flow.SetSortOrder(models.SortByStars)
+
+	// カテゴリ名順に並べ、カテゴリのないテンプレートは最後。カテゴリ内では推奨が先
+	var names []string
+	for _, template := range flow.templates {
+		names = append(names, template.Name)
+	}
+	assert.Equal(t, []string{"worker", "api", "web", "misc"}, names)
+}
+
+func TestFormatTemplateDetails_Registry(t *testing.T) {
+	template := models.Template{FullName: "acme/go-service", Title: "Go service", Ref: "v1.4.0", Description: "Approved"}
+	assert.Equal(t, "acme/go-service · pinned to v1.4.0 · Approved", formatTemplateDetails(template))
+}
+
+// filterSurveyExecutor は質問名ごとに選択肢を返すモック
+type filterSurveyExecutor struct {
+	answers map[string]string
+	asked   []string
+}
+
+func (f *filterSurveyExecutor) Ask(questions []*survey.Question, response interface{}) error {
+	name := questions[0].Name
+	f.asked = append(f.asked, name)
+	if answer, ok := f.answers[name]; ok {
+		*response.(*string) = answer
+	}
+	return nil
+}
+
+// TestQuestionFlow_FilterTemplates はテンプレートの絞り込みをテスト
+func TestQuestionFlow_FilterTemplates(t *testing.T) {
+	templates := []models.Template{
+		{Name: "api", Language: "Go", Topics: []string{"backend"}},
+		{Name: "worker", Language: "Rust", Topics: []string{"backend"}},
+		{Name: "web", Language: "TypeScript", Topics: []string{"frontend"}},
+	}
+
+	t.Run("対話で絞り込む", func(t *testing.T) {
+		executor := &filterSurveyExecutor{answers: map[string]string{
+			"topic":    "backend (2)",
+			"language": "Go (1)",
+		}}
+		flow := NewQuestionFlow(templates)
+		flow.surveyExecutor = executor
+
+		require.NoError(t, flow.filterTemplates(false))
+		assert.Equal(t, []string{"topic", "language"}, executor.asked)
+		require.Len(t, flow.templates, 1)
+		assert.Equal(t, "api", flow.templates[0].Name)
+	})
+
+	t.Run("すべてを選ぶと絞り込まない", func(t *testing.T) {
+		executor := &filterSurveyExecutor{}
+		flow := NewQuestionFlow(templates)
+		flow.surveyExecutor = executor
+
+		require.NoError(t, flow.filterTemplates(false))
+		assert.Len(t, flow.templates, 3)
+	})
+
+	t.Run("指定済みのフィルターは質問しない", func(t *testing.T) {
+		executor := &filterSurveyExecutor{}
+		flow := NewQuestionFlow(templates)
+		flow.surveyExecutor = executor
+		flow.SetFilter(models.TemplateFilter{Topics: []string{"frontend"}})
+
+		require.NoError(t, flow.filterTemplates(false))
+		assert.Empty(t, executor.asked)
+		require.Len(t, flow.templates, 1)
+		assert.Equal(t, "web", flow.templates[0].Name)
+	})
+
+	t.Run("一致しなければエラー", func(t *testing.T) {
+		flow := NewQuestionFlow(templates)
+		flow.SetFilter(models.TemplateFilter{Language: "Haskell"})
+
+		err := flow.filterTemplates(false)
+		assert.ErrorContains(t, err, "No templates match language Haskell")
+	})
+}
+
 // MockSurveyExecutor はテスト用のモック
 type MockSurveyExecutor struct {
 	MockAnswers *Answers
@@ -210,6 +377,136 @@ func TestQuestionFlow_ExecuteWithMock(t *testing.T) {
 	assert.Equal(t, 3, mockExecutor.CallCount) // テンプレート選択 + 基本質問 + 条件付き質問
 }
 
+// TestQuestionFlow_ExecuteWithOwners はオーナー選択のテスト
+func TestQuestionFlow_ExecuteWithOwners(t *testing.T) {
+	mockExecutor := &MockSurveyExecutor{MockAnswers: &Answers{
+		ProjectName:  "test-project",
+		CreateGitHub: true,
+		Owner:        "acme",
+	}}
+	flow := NewQuestionFlow(nil)
+	flow.surveyExecutor = mockExecutor
+	flow.SetOwnerLoader(func() ([]string, error) {
+		return []string{"me", "acme"}, nil
+	}, "acme")
+
+	config, err := flow.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "acme", config.Owner)
+
+	// 条件付き質問にオーナーとデフォルト値が含まれる
+	questions := flow.CreateConditionalQuestions()
+	require.Len(t, questions, 4)
+	assert.Equal(t, "owner", questions[0].Name)
+	assert.Equal(t, "acme", questions[0].Prompt.(*survey.Select).Default)
+
+	// 選べるオーナーが自分だけなら質問しない
+	flow.SetOwnerLoader(func() ([]string, error) {
+		return []string{"me"}, nil
+	}, "")
+	require.NoError(t, flow.loadOwners())
+	assert.Len(t, flow.CreateConditionalQuestions(), 3)
+
+	// 一覧の取得に失敗したらエラーになる
+	flow.SetOwnerLoader(func() ([]string, error) {
+		return nil, errors.New("unauthorized")
+	}, "")
+	_, err = flow.Execute()
+	assert.ErrorContains(t, err, "failed to list repository owners")
+}
+
+func TestQuestionFlow_ExecuteWithRepositoryOptions(t *testing.T) {
+	mockExecutor := &MockSurveyExecutor{MockAnswers: &Answers{
+		ProjectName:  "test-project",
+		CreateGitHub: true,
+		Topics:       "Go, cli  tools",
+		Homepage:     " https://example.com ",
+	}}
+	flow := NewQuestionFlow(nil)
+	flow.surveyExecutor = mockExecutor
+
+	config, err := flow.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "cli", "tools"}, config.Repository.Topics)
+	assert.Equal(t, "https://example.com", config.Repository.Homepage)
+
+	// 不正なトピックやホームページは入力時に弾く
+	assert.NoError(t, validateTopics("go, cli"))
+	assert.Error(t, validateTopics("not_a_topic"))
+	assert.NoError(t, validateHomepage(""))
+	assert.Error(t, validateHomepage("example.com"))
+}
+
+// TestQuestionFlow_ExecuteWithManifest はマニフェスト宣言変数の質問テスト
+func TestQuestionFlow_ExecuteWithManifest(t *testing.T) {
+	templates := []models.Template{
+		{Name: "test-template", FullName: "user/test-template", Stars: 5, Language: "Go"},
+	}
+
+	manifest, err := models.ParseManifest([]byte(`
+variables:
+  - name: use_ci
+    type: bool
+    default: true
+  - name: database
+    type: choice
+    choices: [postgres, mysql]
+    default: mysql
+  - name: port
+    type: int
+    default: 8080
+`))
+	require.NoError(t, err)
+
+	mockExecutor := &MockSurveyExecutor{MockAnswers: &Answers{
+		Template:    "user/test-template (⭐ 5) [Go]",
+		ProjectName: "test-project",
+	}}
+	flow := NewQuestionFlow(templates)
+	flow.surveyExecutor = mockExecutor
+	flow.SetManifestLoader(func(template *models.Template) (*models.TemplateManifest, error) {
+		assert.Equal(t, "user/test-template", template.FullName)
+		return manifest, nil
+	})
+
+	config, err := flow.Execute()
+	require.NoError(t, err)
+
+	// モックは値を書き込まないのでデフォルト値が使われる
+	assert.Equal(t, map[string]interface{}{"use_ci": true, "database": "mysql", "port": 8080}, config.Variables)
+	assert.Same(t, manifest, config.Manifest)
+	assert.Equal(t, 5, mockExecutor.CallCount) // テンプレート選択 + 基本質問 + 変数3件
+}
+
+func TestQuestionFlow_CreateVariableQuestion(t *testing.T) {
+	flow := NewQuestionFlow([]models.Template{})
+
+	tests := []struct {
+		name     string
+		variable models.TemplateVariable
+		expected interface{}
+	}{
+		{name: "string", variable: models.TemplateVariable{Name: "a", Type: models.VariableTypeString}, expected: &survey.Input{}},
+		{name: "int", variable: models.TemplateVariable{Name: "a", Type: models.VariableTypeInt}, expected: &survey.Input{}},
+		{name: "bool", variable: models.TemplateVariable{Name: "a", Type: models.VariableTypeBool}, expected: &survey.Confirm{}},
+		{name: "choice", variable: models.TemplateVariable{Name: "a", Type: models.VariableTypeChoice, Choices: []string{"x"}}, expected: &survey.Select{}},
+		{name: "multi-choice", variable: models.TemplateVariable{Name: "a", Type: models.VariableTypeMultiChoice, Choices: []string{"x"}}, expected: &survey.MultiSelect{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := flow.CreateVariableQuestion(tt.variable)
+			assert.Equal(t, "a", question.Name)
+			assert.IsType(t, tt.expected, question.Prompt)
+		})
+	}
+
+	// 正規表現バリデーションが質問に反映される
+	question := flow.CreateVariableQuestion(models.TemplateVariable{Name: "a", Type: models.VariableTypeString, Validate: "^[a-z]+$"})
+	assert.NoError(t, question.Validate("abc"))
+	assert.Error(t, question.Validate("ABC"))
+}
+
 func TestFormatDescription(t *testing.T) {
 	tests := []struct {
 		name        string
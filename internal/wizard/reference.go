@@ -0,0 +1,49 @@
+package wizard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// TemplateFromReference creates the template a reference names without discovery:
+// a git URL, a local directory or owner/repo, each optionally followed by //path/to/template
+func TemplateFromReference(reference string) (*models.Template, error) {
+	repository, subdir, err := models.SplitTemplatePath(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	var template *models.Template
+	switch {
+	case IsGitURL(repository):
+		template = GitTemplate(repository, "")
+	case IsLocalTemplateRef(repository):
+		template, err = LocalTemplate(repository)
+	default:
+		template, err = GitHubTemplate(repository)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := template.WithPath(subdir)
+	return &result, nil
+}
+
+// GitHubTemplate creates a template for an owner/repo repository that was not discovered
+func GitHubTemplate(repository string) (*models.Template, error) {
+	owner, name, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, models.NewValidationError(fmt.Sprintf("Invalid template '%s': expected owner/repo", repository))
+	}
+
+	return &models.Template{
+		Name:       name,
+		FullName:   repository,
+		Owner:      owner,
+		Topics:     []string{},
+		IsTemplate: true,
+	}, nil
+}
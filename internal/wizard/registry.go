@@ -0,0 +1,118 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// registryFetchTimeout bounds the download of a registry given as a URL
+const registryFetchTimeout = 10 * time.Second
+
+// LoadRegistry reads a template registry from an http(s) URL, a file in a GitHub repository
+// (owner/repo//path/to/registry.yaml) or a local file
+func LoadRegistry(ctx context.Context, client github.Client, location string) (*models.TemplateRegistry, error) {
+	data, err := readRegistry(ctx, client, location)
+	if err != nil {
+		return nil, models.NewGitHubError(fmt.Sprintf("Failed to read template registry '%s'", location), err)
+	}
+
+	registry, err := models.ParseRegistry(data)
+	if err != nil {
+		return nil, models.NewValidationError(fmt.Sprintf("Invalid template registry '%s': %v", location, err))
+	}
+	return registry, nil
+}
+
+// readRegistry returns the contents of the registry at location
+func readRegistry(ctx context.Context, client github.Client, location string) ([]byte, error) {
+	if strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://") {
+		return fetchRegistry(ctx, location)
+	}
+
+	if !IsLocalTemplateRef(location) {
+		repository, file, err := models.SplitTemplatePath(location)
+		if err != nil {
+			return nil, err
+		}
+		if file == "" {
+			return nil, fmt.Errorf("expected a URL, owner/repo//path/to/registry.yaml or a local file")
+		}
+
+		data, err := client.GetFileContent(ctx, repository, file)
+		if err == nil && data == nil {
+			err = fmt.Errorf("%s not found in %s", file, repository)
+		}
+		return data, err
+	}
+
+	path, err := localTemplatePath(location)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// fetchRegistry downloads a registry over http(s)
+func fetchRegistry(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, registryFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// ApplyRegistry merges registry entries into the templates. Listed templates that were discovered
+// are annotated in place; the others are added. Entries that name no usable template are reported to warnings.
+func ApplyRegistry(templates []models.Template, registry *models.TemplateRegistry, warnings io.Writer) []models.Template {
+	result := make([]models.Template, len(templates))
+	copy(result, templates)
+
+	for _, entry := range registry.Templates {
+		template, err := TemplateFromReference(entry.Template)
+		if err != nil {
+			fmt.Fprintf(warnings, "⚠️  Skipping registry template '%s': %v\n", entry.Template, err)
+			continue
+		}
+
+		index := findTemplate(result, template.GetReference())
+		if index == -1 {
+			result = append(result, entry.Apply(*template))
+		} else if !result[index].Recommended {
+			// The first registry listing a template wins
+			result[index] = entry.Apply(result[index])
+		}
+	}
+
+	return result
+}
+
+// findTemplate returns the index of the template with the reference (case-insensitive), or -1
+func findTemplate(templates []models.Template, reference string) int {
+	for i, template := range templates {
+		if strings.EqualFold(template.GetReference(), reference) {
+			return i
+		}
+	}
+	return -1
+}
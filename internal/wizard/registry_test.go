@@ -0,0 +1,125 @@
+package wizard
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRegistryYAML = `templates:
+  - template: acme/go-service
+    name: Go service
+    category: Backend
+    ref: v1.4.0
+  - template: acme/templates//web
+    category: Frontend
+`
+
+func TestLoadRegistry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/registry.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testRegistryYAML))
+	}))
+	defer server.Close()
+
+	registryFile := filepath.Join(t.TempDir(), "registry.yaml")
+	require.NoError(t, os.WriteFile(registryFile, []byte(testRegistryYAML), 0644))
+
+	client := github.NewSimpleMockClient()
+	client.Files = map[string][]byte{"acme/platform/registry/templates.yaml": []byte(testRegistryYAML)}
+
+	// URL・リポジトリ内のファイル・ローカルファイルから読み込める
+	for _, location := range []string{
+		server.URL + "/registry.yaml",
+		"acme/platform//registry/templates.yaml",
+		registryFile,
+		"file://" + filepath.ToSlash(registryFile),
+	} {
+		t.Run(location, func(t *testing.T) {
+			registry, err := LoadRegistry(context.Background(), client, location)
+			require.NoError(t, err)
+			assert.Len(t, registry.Templates, 2)
+		})
+	}
+
+	// 読めないレジストリはエラー
+	for _, location := range []string{
+		server.URL + "/missing.yaml",
+		"acme/platform//missing.yaml",
+		"acme/platform",
+		filepath.Join(t.TempDir(), "missing.yaml"),
+	} {
+		_, err := LoadRegistry(context.Background(), client, location)
+		assert.Error(t, err, location)
+	}
+}
+
+func TestApplyRegistry(t *testing.T) {
+	registry, err := models.ParseRegistry([]byte(testRegistryYAML + "  - template: ./missing-template\n"))
+	require.NoError(t, err)
+
+	templates := []models.Template{
+		{Name: "go-service", FullName: "acme/go-service", Stars: 7, IsTemplate: true},
+		{Name: "rust-cli", FullName: "acme/rust-cli", IsTemplate: true},
+	}
+
+	var warnings bytes.Buffer
+	result := ApplyRegistry(templates, registry, &warnings)
+	require.Len(t, result, 3)
+
+	// 検出済みのテンプレートに注釈を付ける
+	assert.Equal(t, "Go service", result[0].Title)
+	assert.Equal(t, "v1.4.0", result[0].Ref)
+	assert.Equal(t, 7, result[0].Stars)
+	assert.True(t, result[0].Recommended)
+	assert.False(t, result[1].Recommended)
+	assert.False(t, templates[0].Recommended)
+
+	// 検出されていないテンプレートは追加する
+	assert.Equal(t, "acme/templates//web", result[2].GetReference())
+	assert.Equal(t, "Frontend", result[2].Category)
+	assert.True(t, result[2].Recommended)
+
+	// 使えないエントリは警告して除外する
+	assert.Contains(t, warnings.String(), "Skipping registry template './missing-template'")
+
+	// 同じテンプレートは最初のレジストリが優先される
+	second, err := models.ParseRegistry([]byte("templates:\n  - template: acme/go-service\n    category: Other\n"))
+	require.NoError(t, err)
+	result = ApplyRegistry(result, second, &warnings)
+	require.Len(t, result, 3)
+	assert.Equal(t, "Backend", result[0].Category)
+}
+
+func TestTemplateFromReference(t *testing.T) {
+	template, err := TemplateFromReference("acme/templates//services/go")
+	require.NoError(t, err)
+	assert.Equal(t, "acme/templates", template.FullName)
+	assert.Equal(t, "acme", template.Owner)
+	assert.Equal(t, "go", template.Name)
+
+	template, err = TemplateFromReference("git@gitea.example.com:platform/service.git")
+	require.NoError(t, err)
+	assert.Equal(t, models.SourceGit, template.Source)
+
+	template, err = TemplateFromReference(t.TempDir())
+	require.NoError(t, err)
+	assert.True(t, template.IsLocal())
+
+	for _, reference := range []string{"acme", "acme/templates/extra", "acme/templates//../web"} {
+		_, err := TemplateFromReference(reference)
+		assert.Error(t, err, reference)
+	}
+}
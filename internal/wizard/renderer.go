@@ -0,0 +1,412 @@
+package wizard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// binarySniffLength is the number of leading bytes inspected to detect binary files
+const binarySniffLength = 8000
+
+var templateErrorLinePattern = regexp.MustCompile(`^(\d+)(?::\d+)?: (.*)$`)
+
+// templateFuncs are helper functions available in template files
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"replace": func(old, new, s string) string {
+		return strings.ReplaceAll(s, old, new)
+	},
+	"join": func(sep string, items []string) string {
+		return strings.Join(items, sep)
+	},
+	"contains": func(item string, collection interface{}) bool {
+		switch c := collection.(type) {
+		case []string:
+			for _, v := range c {
+				if v == item {
+					return true
+				}
+			}
+		case string:
+			return strings.Contains(c, item)
+		}
+		return false
+	},
+}
+
+// RenderError represents a rendering failure in a single file
+type RenderError struct {
+	Path string
+	Line int
+	Err  error
+}
+
+// Error satisfies the error interface
+func (re *RenderError) Error() string {
+	if re.Line > 0 {
+		return fmt.Sprintf("%s:%d: %v", re.Path, re.Line, re.Err)
+	}
+	return fmt.Sprintf("%s: %v", re.Path, re.Err)
+}
+
+// Unwrap returns the underlying error
+func (re *RenderError) Unwrap() error {
+	return re.Err
+}
+
+// TemplateRenderer renders template files with project variables
+type TemplateRenderer struct {
+	enabled    bool
+	data       map[string]interface{}
+	leftDelim  string
+	rightDelim string
+	ignore     []string
+	conditions []models.ManifestCondition
+}
+
+// NewTemplateRenderer creates a renderer from project configuration
+func NewTemplateRenderer(config *models.ProjectConfig) *TemplateRenderer {
+	renderer := &TemplateRenderer{
+		data:       config.GetTemplateData(),
+		leftDelim:  "{{",
+		rightDelim: "}}",
+	}
+
+	if config.Manifest != nil {
+		renderer.enabled = true
+		renderer.leftDelim, renderer.rightDelim = config.Manifest.GetDelimiters()
+		renderer.ignore = config.Manifest.Ignore
+		renderer.conditions = config.Manifest.Conditions
+	}
+
+	return renderer
+}
+
+// RenderString renders a single template text
+func (r *TemplateRenderer) RenderString(name, text string) (string, error) {
+	tmpl, err := template.New(name).
+		Delims(r.leftDelim, r.rightDelim).
+		Funcs(templateFuncs).
+		Option("missingkey=error").
+		Parse(text)
+	if err != nil {
+		return "", newRenderError(name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, r.data); err != nil {
+		return "", newRenderError(name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// RenderTree renders every path and text file under root in place
+func (r *TemplateRenderer) RenderTree(root string) error {
+	staging, err := os.MkdirTemp(filepath.Dir(root), ".gh-wizard-render-*")
+	if err != nil {
+		return models.NewProjectError("failed to create staging directory", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err := r.CopyTree(root, staging, nil); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(root); err != nil {
+		return models.NewProjectError("failed to replace rendered directory", err)
+	}
+	if err := os.Rename(staging, root); err != nil {
+		return models.NewProjectError("failed to replace rendered directory", err)
+	}
+
+	return nil
+}
+
+// CopyTree copies srcDir into dstDir, rendering path segments and text file contents.
+// Entries whose first path segment is listed in exclude are skipped, and .git is always copied verbatim.
+// Without a manifest the tree is copied as-is.
+func (r *TemplateRenderer) CopyTree(srcDir, dstDir string, exclude []string) error {
+	var renderErrors []error
+
+	err := filepath.Walk(srcDir, func(srcPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(srcDir, srcPath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if relPath == "." {
+			return os.MkdirAll(dstDir, 0755)
+		}
+
+		if isExcludedPath(relPath, exclude) {
+			return skipEntry(info)
+		}
+
+		verbatim := !r.enabled || isExcludedPath(relPath, []string{".git"})
+		dstRel := relPath
+		if !verbatim {
+			rendered, ok, err := r.RenderPath(relPath)
+			if err != nil {
+				renderErrors = append(renderErrors, err)
+				return skipEntry(info)
+			}
+			if !ok {
+				return skipEntry(info)
+			}
+			dstRel = rendered
+		}
+		dstPath := filepath.Join(dstDir, filepath.FromSlash(dstRel))
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(dstPath, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(srcPath)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, dstPath)
+		case !info.Mode().IsRegular():
+			return nil
+		}
+
+		content, err := os.ReadFile(srcPath)
+		if err != nil {
+			return err
+		}
+
+		if !verbatim && r.shouldRender(relPath, content) {
+			rendered, err := r.RenderString(relPath, string(content))
+			if err != nil {
+				renderErrors = append(renderErrors, err)
+				return nil
+			}
+			content = []byte(rendered)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(dstPath, content, info.Mode().Perm())
+	})
+	if err != nil {
+		return models.NewProjectError("failed to copy template files", err)
+	}
+
+	if len(renderErrors) > 0 {
+		return models.NewTemplateError(
+			fmt.Sprintf("failed to render %d template file(s)", len(renderErrors)),
+			errors.Join(renderErrors...),
+		)
+	}
+
+	return nil
+}
+
+// RenderPath renders each segment of a slash-separated relative path.
+// It reports false when a manifest condition evaluates false or a segment renders empty.
+func (r *TemplateRenderer) RenderPath(relPath string) (string, bool, error) {
+	for _, condition := range r.conditions {
+		conditionPath := strings.Trim(condition.Path, "/")
+		if relPath != conditionPath && !strings.HasPrefix(relPath, conditionPath+"/") {
+			continue
+		}
+
+		result, err := r.RenderString(relPath, condition.If)
+		if err != nil {
+			return "", false, err
+		}
+		if !isTruthy(result) {
+			return "", false, nil
+		}
+	}
+
+	segments := strings.Split(relPath, "/")
+	for i, segment := range segments {
+		if !strings.Contains(segment, r.leftDelim) {
+			continue
+		}
+
+		rendered, err := r.RenderString(relPath, segment)
+		if err != nil {
+			return "", false, err
+		}
+
+		rendered = strings.TrimSpace(rendered)
+		if rendered == "" {
+			return "", false, nil
+		}
+		if rendered == "." || rendered == ".." || strings.ContainsAny(rendered, `/\`) {
+			return "", false, &RenderError{Path: relPath, Err: fmt.Errorf("invalid path segment '%s'", rendered)}
+		}
+		segments[i] = rendered
+	}
+
+	return strings.Join(segments, "/"), true, nil
+}
+
+// shouldRender reports whether the file contents need rendering
+func (r *TemplateRenderer) shouldRender(relPath string, content []byte) bool {
+	return !r.IsIgnored(relPath) &&
+		!isBinaryContent(content) &&
+		bytes.Contains(content, []byte(r.leftDelim))
+}
+
+// IsIgnored reports whether the slash-separated relative path matches the ignore list.
+// Patterns follow gitignore: a pattern without a slash matches at any depth, "**" spans any number
+// of directories, and a pattern matching a directory ("dir/", "dir/**") matches everything below it.
+func (r *TemplateRenderer) IsIgnored(relPath string) bool {
+	segments := strings.Split(relPath, "/")
+	for _, pattern := range r.ignore {
+		pattern = strings.TrimSuffix(strings.TrimSuffix(pattern, "**"), "/")
+		if pattern == "" {
+			continue
+		}
+		if !strings.Contains(pattern, "/") {
+			pattern = "**/" + pattern
+		}
+
+		patternSegments := strings.Split(pattern, "/")
+		for i := 1; i <= len(segments); i++ {
+			if matchSegments(patternSegments, segments[:i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments, where a "**" segment matches
+// zero or more path segments and the others are path.Match patterns
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// isExcludedPath reports whether the first segment of relPath is in the exclusion list
+func isExcludedPath(relPath string, exclude []string) bool {
+	first, _, _ := strings.Cut(relPath, "/")
+	for _, name := range exclude {
+		if first == name {
+			return true
+		}
+	}
+	return false
+}
+
+// skipEntry skips a directory subtree or a single file during a walk
+func skipEntry(info os.FileInfo) error {
+	if info.IsDir() {
+		return filepath.SkipDir
+	}
+	return nil
+}
+
+// isTruthy interprets a rendered condition result
+func isTruthy(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "false", "0", "no", "<no value>":
+		return false
+	default:
+		return true
+	}
+}
+
+// newRenderError converts a text/template error into a RenderError with line information
+func newRenderError(name string, err error) *RenderError {
+	message := strings.TrimPrefix(err.Error(), "template: "+name+":")
+	if matches := templateErrorLinePattern.FindStringSubmatch(message); matches != nil {
+		line, _ := strconv.Atoi(matches[1])
+		return &RenderError{Path: name, Line: line, Err: errors.New(matches[2])}
+	}
+	return &RenderError{Path: name, Err: err}
+}
+
+// isBinaryContent detects binary files the same way git does (NUL bytes near the start)
+func isBinaryContent(content []byte) bool {
+	sniff := content
+	if len(sniff) > binarySniffLength {
+		sniff = sniff[:binarySniffLength]
+	}
+	return bytes.IndexByte(sniff, 0) != -1 || !utf8.Valid(content)
+}
+
+// legacyPlaceholders returns the README.md placeholders replaced in templates without a manifest
+func legacyPlaceholders(config *models.ProjectConfig) map[string]string {
+	return map[string]string{
+		"{{PROJECT_NAME}}": config.Name,
+		"{{project_name}}": config.Name,
+		"{{DESCRIPTION}}":  config.Description,
+		"{{description}}":  config.Description,
+		"${PROJECT_NAME}":  config.Name,
+		"${project_name}":  config.Name,
+		"${DESCRIPTION}":   config.Description,
+		"${description}":   config.Description,
+	}
+}
+
+// applyLegacyPlaceholders replaces the legacy placeholders in the README.md of dir, or writes a basic
+// README when there is none. Templates without a manifest get only this, both when a project is created
+// and when the template is rendered again for update and diff, so the two agree.
+func applyLegacyPlaceholders(config *models.ProjectConfig, dir string) error {
+	readmePath := filepath.Join(dir, "README.md")
+
+	content, err := os.ReadFile(readmePath)
+	if os.IsNotExist(err) {
+		return writeBasicReadme(config, dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	contentStr := string(content)
+	for placeholder, value := range legacyPlaceholders(config) {
+		if value != "" { // Don't replace if value is empty
+			contentStr = strings.ReplaceAll(contentStr, placeholder, value)
+		}
+	}
+
+	return os.WriteFile(readmePath, []byte(contentStr), 0644)
+}
+
+// writeBasicReadme writes the README of a project created without template contents
+func writeBasicReadme(config *models.ProjectConfig, dir string) error {
+	readmeContent := fmt.Sprintf("# %s\n\n%s\n", config.Name, config.Description)
+	return os.WriteFile(filepath.Join(dir, "README.md"), []byte(readmeContent), 0644)
+}
@@ -0,0 +1,236 @@
+package wizard
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRenderConfig(manifest *models.TemplateManifest) *models.ProjectConfig {
+	return &models.ProjectConfig{
+		Name:        "billing",
+		Description: "Billing service",
+		Variables: map[string]interface{}{
+			"use_ci":   true,
+			"features": []string{"auth", "metrics"},
+		},
+		Manifest: manifest,
+	}
+}
+
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	for filePath, content := range files {
+		fullPath := filepath.Join(root, filePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+}
+
+func TestTemplateRenderer_RenderString(t *testing.T) {
+	renderer := NewTemplateRenderer(newTestRenderConfig(&models.TemplateManifest{}))
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "builtin values", text: "# {{.Name}}\n{{.Description}}", expected: "# billing\nBilling service"},
+		{name: "manifest variable", text: "{{if .use_ci}}ci{{end}}", expected: "ci"},
+		{name: "variable via Vars", text: "{{.Vars.use_ci}}", expected: "true"},
+		{name: "functions", text: `{{upper .Name}} {{join "," .features}} {{contains "auth" .features}}`, expected: "BILLING auth,metrics true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := renderer.RenderString("test", tt.text)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestTemplateRenderer_RenderTree(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"package.json":                 `{"name": "{{.Name}}"}`,
+		"cmd/main.go":                  `package main // {{.Description}}`,
+		".github/workflows/ci.yml":     "run: ${{ github.sha }}",
+		"charts/values.yaml":           "name: {{ .Values.name }}",
+		"docs/plain.md":                "no placeholders",
+		".github/workflows/deploy.yml": "name: [[.Name]]",
+	})
+	binary := []byte{0x89, 'P', 'N', 'G', 0x00, '{', '{'}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "logo.png"), binary, 0644))
+
+	manifest := &models.TemplateManifest{Ignore: []string{".github/workflows/ci.yml", "charts/**"}}
+	require.NoError(t, NewTemplateRenderer(newTestRenderConfig(manifest)).RenderTree(root))
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(root, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	assert.Equal(t, `{"name": "billing"}`, read("package.json"))
+	assert.Equal(t, `package main // Billing service`, read("cmd/main.go"))
+	// 無視リストのファイルはそのまま
+	assert.Equal(t, "run: ${{ github.sha }}", read(".github/workflows/ci.yml"))
+	assert.Equal(t, "name: {{ .Values.name }}", read("charts/values.yaml"))
+	// デフォルトの区切り文字では [[ ]] は処理されない
+	assert.Equal(t, "name: [[.Name]]", read(".github/workflows/deploy.yml"))
+	// バイナリファイルはスキップ
+	assert.Equal(t, string(binary), read("logo.png"))
+}
+
+func TestTemplateRenderer_CustomDelimiters(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"deploy.yml": "name: [[.Name]]\nrun: ${{ github.sha }}",
+	})
+
+	manifest := &models.TemplateManifest{Delimiters: &models.ManifestDelimiters{Left: "[[", Right: "]]"}}
+	require.NoError(t, NewTemplateRenderer(newTestRenderConfig(manifest)).RenderTree(root))
+
+	content, err := os.ReadFile(filepath.Join(root, "deploy.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "name: billing\nrun: ${{ github.sha }}", string(content))
+}
+
+func TestTemplateRenderer_RenderErrors(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"README.md":  "# {{.Name}}\n\n{{.unknown}}\n",
+		"broken.txt": "line1\n{{.Name}\n",
+		"ok.txt":     "{{.Name}}",
+	})
+
+	err := NewTemplateRenderer(newTestRenderConfig(&models.TemplateManifest{})).RenderTree(root)
+	require.Error(t, err)
+
+	var wizardErr *models.WizardError
+	require.True(t, errors.As(err, &wizardErr))
+	assert.Equal(t, models.ErrorTypeTemplate, wizardErr.Type)
+	assert.Contains(t, err.Error(), "2 template file(s)")
+
+	var renderErr *RenderError
+	require.True(t, errors.As(err, &renderErr))
+	assert.NotZero(t, renderErr.Line)
+
+	assert.Contains(t, err.Error(), "README.md:3:")
+	assert.Contains(t, err.Error(), "broken.txt:2:")
+}
+
+func TestTemplateRenderer_IsIgnored(t *testing.T) {
+	renderer := &TemplateRenderer{ignore: []string{"*.png", "charts/", "vendor/**", "docs/*.md", "**/fixtures/*.json", "config/**/secret.txt"}}
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"logo.png", true},
+		{"assets/img/logo.png", true},
+		{"charts", true},
+		{"charts/templates/deploy.yaml", true},
+		{"vendor/lib/a.go", true},
+		{"docs/index.md", true},
+		{"docs/sub/index.md", false},
+		// "**/" はどの深さにもマッチする
+		{"fixtures/a.json", true},
+		{"api/fixtures/a.json", true},
+		{"services/api/testdata/fixtures/a.json", true},
+		{"services/api/fixtures/nested/a.json", false},
+		{"config/secret.txt", true},
+		{"config/prod/eu/secret.txt", true},
+		{"other/prod/secret.txt", false},
+		{"main.go", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, renderer.IsIgnored(tt.path))
+		})
+	}
+}
+
+func TestTemplateRenderer_RenderPath(t *testing.T) {
+	manifest := &models.TemplateManifest{
+		Conditions: []models.ManifestCondition{
+			{Path: ".github/workflows", If: "{{ .use_ci }}"},
+			{Path: "docs/", If: `{{ contains "docs" .features }}`},
+		},
+	}
+	renderer := NewTemplateRenderer(newTestRenderConfig(manifest))
+
+	tests := []struct {
+		path     string
+		expected string
+		ok       bool
+	}{
+		{path: "cmd/{{.Name}}/main.go", expected: "cmd/billing/main.go", ok: true},
+		{path: "{{upper .Name}}.md", expected: "BILLING.md", ok: true},
+		{path: ".github/workflows/ci.yml", expected: ".github/workflows/ci.yml", ok: true},
+		{path: "docs", ok: false},
+		{path: "docs/index.md", ok: false},
+		{path: `{{if contains "tracing" .features}}tracing.go{{end}}`, ok: false},
+		{path: `{{if contains "auth" .features}}auth.go{{end}}`, expected: "auth.go", ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result, ok, err := renderer.RenderPath(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+
+	// パス区切りを含む値はエラー
+	renderer.data["Name"] = "../escape"
+	_, _, err := renderer.RenderPath("{{.Name}}/main.go")
+	assert.Error(t, err)
+}
+
+func TestTemplateRenderer_CopyTree(t *testing.T) {
+	src := t.TempDir()
+	writeTestFiles(t, src, map[string]string{
+		".git/HEAD":                         "ref: refs/heads/{{.Name}}",
+		".gitignore":                        "node_modules",
+		".github/workflows/ci.yml":          "name: {{.Name}}",
+		"cmd/{{.Name}}/main.go":             "package main",
+		"{{if not .use_ci}}skip.txt{{end}}": "skipped",
+		models.ManifestFileName:             "variables: []",
+	})
+
+	manifest := &models.TemplateManifest{
+		Conditions: []models.ManifestCondition{{Path: ".github", If: "{{ not .use_ci }}"}},
+	}
+	dst := filepath.Join(t.TempDir(), "project")
+	err := NewTemplateRenderer(newTestRenderConfig(manifest)).CopyTree(src, dst, []string{".git", models.ManifestFileName})
+	require.NoError(t, err)
+
+	assert.NoDirExists(t, filepath.Join(dst, ".git"))
+	assert.NoFileExists(t, filepath.Join(dst, models.ManifestFileName))
+	// ".git" の除外は ".gitignore" に影響しない
+	assert.FileExists(t, filepath.Join(dst, ".gitignore"))
+	assert.FileExists(t, filepath.Join(dst, "cmd", "billing", "main.go"))
+	// 条件が false のディレクトリ、空になるファイル名はスキップ
+	assert.NoDirExists(t, filepath.Join(dst, ".github"))
+	entries, err := os.ReadDir(dst)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// マニフェストなしの場合はそのままコピー
+	plain := filepath.Join(t.TempDir(), "plain")
+	require.NoError(t, NewTemplateRenderer(&models.ProjectConfig{Name: "billing"}).CopyTree(src, plain, []string{".git"}))
+	assert.FileExists(t, filepath.Join(plain, "cmd", "{{.Name}}", "main.go"))
+	content, err := os.ReadFile(filepath.Join(plain, ".github", "workflows", "ci.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "name: {{.Name}}", string(content))
+}
@@ -0,0 +1,18 @@
+package wizard
+
+import (
+	"context"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/github"
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// GrantTeams gives the project's teams access to its newly created repository
+func GrantTeams(ctx context.Context, client github.Client, config *models.ProjectConfig, repoInfo *github.RepositoryInfo) error {
+	for _, grant := range config.Teams {
+		if err := client.GrantTeamAccess(ctx, repoInfo.FullName, grant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,399 @@
+package wizard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+)
+
+// UpdateAction describes what an update did to a single file
+type UpdateAction string
+
+const (
+	UpdateActionAdded    UpdateAction = "added"
+	UpdateActionUpdated  UpdateAction = "updated"
+	UpdateActionMerged   UpdateAction = "merged"
+	UpdateActionRemoved  UpdateAction = "removed"
+	UpdateActionConflict UpdateAction = "conflict"
+	UpdateActionRejected UpdateAction = "rejected"
+	UpdateActionKept     UpdateAction = "kept"
+)
+
+// rejectSuffix is appended to files holding upstream changes that could not be merged
+const rejectSuffix = ".rej"
+
+// TemplateCloner clones a template repository with its history into dir
+type TemplateCloner func(ctx context.Context, template *models.Template, dir string) error
+
+// FileUpdate is the outcome of an update for a single file
+type FileUpdate struct {
+	Path   string
+	Action UpdateAction
+}
+
+// UpdateResult summarizes a template update
+type UpdateResult struct {
+	FromCommit string
+	ToCommit   string
+	Files      []FileUpdate
+}
+
+// UpToDate reports whether the project already used the target commit
+func (ur *UpdateResult) UpToDate() bool {
+	return ur.FromCommit == ur.ToCommit
+}
+
+// HasConflicts reports whether any file needs manual resolution
+func (ur *UpdateResult) HasConflicts() bool {
+	for _, file := range ur.Files {
+		switch file.Action {
+		case UpdateActionConflict, UpdateActionRejected, UpdateActionKept:
+			return true
+		}
+	}
+	return false
+}
+
+// TemplateUpdater applies upstream template changes to a generated project
+type TemplateUpdater struct {
+	projectDir string
+	cloner     TemplateCloner
+}
+
+// NewTemplateUpdater creates an updater for the project in projectDir
+func NewTemplateUpdater(projectDir string) *TemplateUpdater {
+	return &TemplateUpdater{
+		projectDir: projectDir,
+		cloner:     CloneTemplate,
+	}
+}
+
+// Update re-renders the template at the recorded and target commits and merges
+// the upstream changes into the project. Files that cannot be merged cleanly get
+// conflict markers, or a .rej file next to them when markers cannot be written.
+func (tu *TemplateUpdater) Update(ctx context.Context, ref string) (*UpdateResult, error) {
+	if err := requireGitExecutable(); err != nil {
+		return nil, err
+	}
+
+	lock, err := ReadProjectLock(tu.projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := os.MkdirTemp("", "gh-wizard-update-*")
+	if err != nil {
+		return nil, models.NewProjectError("failed to create temporary directory", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	cloneDir, target, err := tu.cloneRecordedTemplate(ctx, lock, workDir, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UpdateResult{FromCommit: lock.Commit, ToCommit: target}
+	if result.UpToDate() {
+		return result, nil
+	}
+
+	baseDir := filepath.Join(workDir, "base")
+	if _, err := renderTemplateAt(ctx, cloneDir, lock.Commit, lock, baseDir); err != nil {
+		return nil, err
+	}
+
+	newDir := filepath.Join(workDir, "new")
+	newConfig, err := renderTemplateAt(ctx, cloneDir, target, lock, newDir)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := tu.merge(ctx, baseDir, newDir, result)
+	if err != nil {
+		return nil, err
+	}
+	result.Files = files
+
+	updated := models.NewProjectLock(newConfig, target)
+	updated.Template = lock.Template
+	updated.CloneURL = lock.CloneURL
+	// Later updates follow the ref asked for last
+	updated.Ref = lock.Ref
+	if ref != "" {
+		updated.Ref = ref
+	}
+	if err := WriteProjectLock(tu.projectDir, updated); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// cloneRecordedTemplate clones the template recorded in the lockfile into workDir and resolves ref
+func (tu *TemplateUpdater) cloneRecordedTemplate(ctx context.Context, lock *models.ProjectLock, workDir, ref string) (string, string, error) {
+	cloneDir := filepath.Join(workDir, "template")
+	if err := tu.cloner(ctx, lock.GetTemplate(), cloneDir); err != nil {
+		return "", "", err
+	}
+
+	target, err := resolveTemplateRef(ctx, cloneDir, ref)
+	if err != nil {
+		return "", "", err
+	}
+
+	return cloneDir, target, nil
+}
+
+// renderTemplateAt renders the template at a commit with the recorded answers into dstDir
+func renderTemplateAt(ctx context.Context, cloneDir, commit string, lock *models.ProjectLock, dstDir string) (*models.ProjectConfig, error) {
+	if err := checkoutCommit(ctx, cloneDir, commit); err != nil {
+		return nil, err
+	}
+
+	sourceDir, err := TemplateSourceDir(cloneDir, lock.GetTemplate())
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest *models.TemplateManifest
+	data, err := os.ReadFile(filepath.Join(sourceDir, models.ManifestFileName))
+	switch {
+	case err == nil:
+		manifest, err = models.ParseManifest(data)
+		if err != nil {
+			return nil, models.NewValidationError(
+				fmt.Sprintf("Invalid manifest at template commit %s: %v", ShortCommit(commit), err),
+			)
+		}
+	case !os.IsNotExist(err):
+		return nil, models.NewProjectError("failed to read template manifest", err)
+	}
+
+	config, err := lock.ToProjectConfig(manifest)
+	if err != nil {
+		return nil, models.NewValidationError(err.Error())
+	}
+
+	exclude := []string{".git", models.ManifestFileName, models.LockFileName}
+	if err := NewTemplateRenderer(config).CopyTree(sourceDir, dstDir, exclude); err != nil {
+		return nil, err
+	}
+
+	// Creation replaces the legacy placeholders of templates without a manifest, so the render must too
+	if manifest == nil {
+		if err := applyLegacyPlaceholders(config, dstDir); err != nil {
+			return nil, models.NewProjectError("failed to render template README", err)
+		}
+	}
+
+	return config, nil
+}
+
+// merge applies the changes between the base and new renders to the project
+func (tu *TemplateUpdater) merge(ctx context.Context, baseDir, newDir string, result *UpdateResult) ([]FileUpdate, error) {
+	baseFiles, err := listFiles(baseDir)
+	if err != nil {
+		return nil, models.NewProjectError("failed to read rendered template", err)
+	}
+	newFiles, err := listFiles(newDir)
+	if err != nil {
+		return nil, models.NewProjectError("failed to read rendered template", err)
+	}
+
+	var files []FileUpdate
+	for _, relPath := range sortedPaths(baseFiles, newFiles) {
+		action, err := tu.mergeFile(ctx, relPath, baseDir, newDir, newFiles[relPath], result)
+		if err != nil {
+			return nil, err
+		}
+		if action != "" {
+			files = append(files, FileUpdate{Path: relPath, Action: action})
+		}
+	}
+
+	return files, nil
+}
+
+// mergeFile performs a three-way merge of a single file; an empty action means nothing changed
+func (tu *TemplateUpdater) mergeFile(ctx context.Context, relPath, baseDir, newDir string, mode fs.FileMode, result *UpdateResult) (UpdateAction, error) {
+	nativePath := filepath.FromSlash(relPath)
+	base, hasBase, err := readOptionalFile(filepath.Join(baseDir, nativePath))
+	if err != nil {
+		return "", err
+	}
+	upstream, hasUpstream, err := readOptionalFile(filepath.Join(newDir, nativePath))
+	if err != nil {
+		return "", err
+	}
+
+	// Unchanged upstream: whatever the project did to the file wins
+	if hasBase == hasUpstream && bytes.Equal(base, upstream) {
+		return "", nil
+	}
+
+	projectPath := filepath.Join(tu.projectDir, nativePath)
+	current, hasCurrent, err := readOptionalFile(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case !hasUpstream:
+		if !hasCurrent {
+			return "", nil
+		}
+		if !bytes.Equal(current, base) {
+			// Deleted upstream but changed locally: keep it for the user to decide
+			return UpdateActionKept, nil
+		}
+		if err := os.Remove(projectPath); err != nil {
+			return "", models.NewProjectError(fmt.Sprintf("failed to remove %s", relPath), err)
+		}
+		return UpdateActionRemoved, nil
+
+	case !hasCurrent:
+		if hasBase {
+			// Deleted locally but changed upstream
+			return UpdateActionRejected, writeProjectFile(projectPath+rejectSuffix, upstream, mode)
+		}
+		return UpdateActionAdded, writeProjectFile(projectPath, upstream, mode)
+
+	case bytes.Equal(current, upstream):
+		return "", nil
+
+	case hasBase && bytes.Equal(current, base):
+		return UpdateActionUpdated, writeProjectFile(projectPath, upstream, mode)
+	}
+
+	if isBinaryContent(current) || isBinaryContent(base) || isBinaryContent(upstream) {
+		return UpdateActionRejected, writeProjectFile(projectPath+rejectSuffix, upstream, mode)
+	}
+
+	merged, conflicts, err := mergeText(ctx, current, base, upstream,
+		"project", "template@"+ShortCommit(result.FromCommit), "template@"+ShortCommit(result.ToCommit))
+	if err != nil {
+		return "", models.NewProjectError(fmt.Sprintf("failed to merge %s", relPath), err)
+	}
+	if err := writeProjectFile(projectPath, merged, mode); err != nil {
+		return "", err
+	}
+	if conflicts {
+		return UpdateActionConflict, nil
+	}
+	return UpdateActionMerged, nil
+}
+
+// requireGitExecutable checks for the git command, which merges and diffs files whatever the git backend is
+func requireGitExecutable() error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return models.NewValidationError("Git command not found. Updating and diffing projects needs Git; please install it.")
+	}
+	return nil
+}
+
+// mergeText runs git merge-file and reports whether conflict markers were written
+func mergeText(ctx context.Context, current, base, upstream []byte, labels ...string) ([]byte, bool, error) {
+	dir, err := os.MkdirTemp("", "gh-wizard-merge-*")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.RemoveAll(dir)
+
+	names := []string{"current", "base", "upstream"}
+	for i, content := range [][]byte{current, base, upstream} {
+		if err := os.WriteFile(filepath.Join(dir, names[i]), content, 0644); err != nil {
+			return nil, false, err
+		}
+	}
+
+	args := []string{"merge-file", "-p"}
+	for _, label := range labels {
+		args = append(args, "-L", label)
+	}
+	args = append(args, names...)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+
+	// git merge-file exits with the number of conflicts; negative values (>127) are errors
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() <= 127 {
+		return output, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return output, false, nil
+}
+
+// listFiles returns the regular files under root keyed by slash-separated relative path
+func listFiles(root string) (map[string]fs.FileMode, error) {
+	files := make(map[string]fs.FileMode)
+	err := filepath.Walk(root, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relPath)] = info.Mode().Perm()
+		return nil
+	})
+	return files, err
+}
+
+// sortedPaths returns the union of the file sets in lexical order
+func sortedPaths(fileSets ...map[string]fs.FileMode) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, files := range fileSets {
+		for relPath := range files {
+			if !seen[relPath] {
+				seen[relPath] = true
+				paths = append(paths, relPath)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// readOptionalFile reads a file, reporting false when it does not exist
+func readOptionalFile(filePath string) ([]byte, bool, error) {
+	content, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, models.NewProjectError(fmt.Sprintf("failed to read %s", filePath), err)
+	}
+	return content, true, nil
+}
+
+// writeProjectFile writes a file into the project, creating parent directories
+func writeProjectFile(filePath string, content []byte, mode fs.FileMode) error {
+	if mode == 0 {
+		mode = 0644
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return models.NewProjectError(fmt.Sprintf("failed to create directory for %s", filePath), err)
+	}
+	if err := os.WriteFile(filePath, content, mode); err != nil {
+		return models.NewProjectError(fmt.Sprintf("failed to write %s", filePath), err)
+	}
+	return nil
+}
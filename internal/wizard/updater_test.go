@@ -0,0 +1,162 @@
+package wizard
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yuki-Sakaguchi/gh-wizard/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runTestGit(t *testing.T, dir string, args ...string) {
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
+func commitTestTemplate(t *testing.T, dir string, files map[string]string, remove ...string) string {
+	writeTestFiles(t, dir, files)
+	for _, name := range remove {
+		require.NoError(t, os.Remove(filepath.Join(dir, name)))
+	}
+	runTestGit(t, dir, "add", "-A")
+	runTestGit(t, dir, "commit", "--quiet", "-m", "update")
+
+	commit, err := ResolveCommit(context.Background(), dir, "HEAD")
+	require.NoError(t, err)
+	return commit
+}
+
+func readTestFile(t *testing.T, path string) string {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestTemplateUpdater_Update(t *testing.T) {
+	templateDir := t.TempDir()
+	runTestGit(t, templateDir, "init", "--quiet")
+	firstCommit := commitTestTemplate(t, templateDir, map[string]string{
+		models.ManifestFileName: "variables:\n  - name: port\n    type: int\n    default: 8080\n",
+		"README.md":             "# {{.Name}}\n\nline2\n\nline3\n",
+		"main.go":               "package main // {{.port}}\n",
+		"config.txt":            "timeout: 10\n",
+		"old.txt":               "old\n",
+		"deleted-locally.txt":   "v1\n",
+	})
+
+	// テンプレートからプロジェクトを生成する
+	template := &models.Template{Name: "service", CloneURL: templateDir}
+	config := &models.ProjectConfig{Name: "billing", Template: template, Variables: map[string]interface{}{"port": 9090}}
+	manifest, err := models.ParseManifest([]byte("variables:\n  - name: port\n    type: int\n"))
+	require.NoError(t, err)
+	config.Manifest = manifest
+
+	projectDir := filepath.Join(t.TempDir(), "billing")
+	require.NoError(t, NewTemplateRenderer(config).CopyTree(templateDir, projectDir, []string{".git", models.ManifestFileName}))
+	require.NoError(t, WriteProjectLock(projectDir, models.NewProjectLock(config, firstCommit)))
+
+	// プロジェクト側の変更
+	writeTestFiles(t, projectDir, map[string]string{
+		"README.md":  "# billing\n\nline2\n\nline3\nlocal notes\n",
+		"config.txt": "timeout: 30\n",
+	})
+	require.NoError(t, os.Remove(filepath.Join(projectDir, "deleted-locally.txt")))
+
+	// テンプレート側の変更
+	secondCommit := commitTestTemplate(t, templateDir, map[string]string{
+		"README.md":           "# {{.Name}}\n\nline2 updated\n\nline3\n",
+		"main.go":             "package main // port {{.port}}\n",
+		"config.txt":          "timeout: 20\n",
+		"new.txt":             "{{.Name}} new\n",
+		"deleted-locally.txt": "v2\n",
+	}, "old.txt")
+
+	result, err := NewTemplateUpdater(projectDir).Update(context.Background(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, firstCommit, result.FromCommit)
+	assert.Equal(t, secondCommit, result.ToCommit)
+	assert.True(t, result.HasConflicts())
+	assert.ElementsMatch(t, []FileUpdate{
+		{Path: "README.md", Action: UpdateActionMerged},
+		{Path: "config.txt", Action: UpdateActionConflict},
+		{Path: "deleted-locally.txt", Action: UpdateActionRejected},
+		{Path: "main.go", Action: UpdateActionUpdated},
+		{Path: "new.txt", Action: UpdateActionAdded},
+		{Path: "old.txt", Action: UpdateActionRemoved},
+	}, result.Files)
+
+	// 双方の変更が取り込まれる
+	assert.Equal(t, "# billing\n\nline2 updated\n\nline3\nlocal notes\n", readTestFile(t, filepath.Join(projectDir, "README.md")))
+	// 記録済みの回答で再レンダリングされる
+	assert.Equal(t, "package main // port 9090\n", readTestFile(t, filepath.Join(projectDir, "main.go")))
+	assert.Equal(t, "billing new\n", readTestFile(t, filepath.Join(projectDir, "new.txt")))
+	assert.NoFileExists(t, filepath.Join(projectDir, "old.txt"))
+	// 競合はマーカー、ローカルで削除したファイルは .rej として残る
+	conflict := readTestFile(t, filepath.Join(projectDir, "config.txt"))
+	assert.Contains(t, conflict, "<<<<<<< project")
+	assert.Contains(t, conflict, "timeout: 30")
+	assert.Contains(t, conflict, "timeout: 20")
+	assert.Equal(t, "v2\n", readTestFile(t, filepath.Join(projectDir, "deleted-locally.txt.rej")))
+
+	lock, err := ReadProjectLock(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, secondCommit, lock.Commit)
+	assert.Equal(t, 9090, lock.Variables["port"])
+
+	// 最新のコミットでは何もしない
+	result, err = NewTemplateUpdater(projectDir).Update(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, result.UpToDate())
+}
+
+func TestTemplateUpdater_MissingLock(t *testing.T) {
+	_, err := NewTemplateUpdater(t.TempDir()).Update(context.Background(), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), models.LockFileName)
+}
+
+// createLegacyProject creates a project with the executor from a git template without a manifest
+func createLegacyProject(t *testing.T) (string, string) {
+	templateDir := t.TempDir()
+	runTestGit(t, templateDir, "init", "--quiet")
+	commitTestTemplate(t, templateDir, map[string]string{
+		"README.md": "# {{PROJECT_NAME}}\n\n${description}\n",
+		"main.go":   "package main\n",
+	})
+
+	projectDir := filepath.Join(t.TempDir(), "billing")
+	config := &models.ProjectConfig{
+		Name:        "billing",
+		Description: "Billing service",
+		Template:    &models.Template{Name: "service", CloneURL: templateDir, Source: models.SourceLocal},
+		LocalPath:   projectDir,
+	}
+	require.NoError(t, NewProjectExecutor(nil).Execute(context.Background(), config))
+
+	content, err := os.ReadFile(filepath.Join(projectDir, "README.md"))
+	require.NoError(t, err)
+	require.Equal(t, "# billing\n\nBilling service\n", string(content))
+	return templateDir, projectDir
+}
+
+func TestTemplateUpdater_LegacyTemplate(t *testing.T) {
+	templateDir, projectDir := createLegacyProject(t)
+
+	// テンプレートの別ファイルだけが変わっても README.md のプレースホルダーは差分にならない
+	commitTestTemplate(t, templateDir, map[string]string{"Makefile": "build:\n"})
+	result, err := NewTemplateUpdater(projectDir).Update(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, []FileUpdate{{Path: "Makefile", Action: UpdateActionAdded}}, result.Files)
+
+	content, err := os.ReadFile(filepath.Join(projectDir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# billing\n\nBilling service\n", string(content))
+}
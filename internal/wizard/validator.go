@@ -268,7 +268,11 @@ func (v *TemplateValidator) Validate(template *models.Template) error {
 		return fmt.Errorf("template is nil")
 	}
 
-	if template.FullName == "" {
+	if template.IsLocal() {
+		if template.CloneURL == "" {
+			return fmt.Errorf("local template path is required")
+		}
+	} else if template.FullName == "" {
 		return fmt.Errorf("template FullName is required")
 	}
 
@@ -298,6 +302,17 @@ func (v *TemplateValidator) ValidateTemplates(templates []models.Template) error
 	return nil
 }
 
+// Valid returns the templates that pass validation
+func (v *TemplateValidator) Valid(templates []models.Template) []models.Template {
+	valid := make([]models.Template, 0, len(templates))
+	for _, template := range templates {
+		if v.Validate(&template) == nil {
+			valid = append(valid, template)
+		}
+	}
+	return valid
+}
+
 // ValidateTemplateSelection validates template selection
 func (v *TemplateValidator) ValidateTemplateSelection(selection string) error {
 	if selection == "" {
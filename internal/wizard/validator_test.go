@@ -398,6 +398,21 @@ func TestTemplateValidator_GetSurveyValidator(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid input type")
 }
 
+func TestTemplateValidator_Valid(t *testing.T) {
+	templates := []models.Template{
+		{Name: "api", FullName: "acme/api", IsTemplate: true},
+		{Name: "demo", FullName: "someone/demo", IsTemplate: false},
+		{Name: "", FullName: "broken/", IsTemplate: true},
+		{Name: "skeleton", CloneURL: "/templates/skeleton", IsTemplate: true, Source: models.SourceLocal},
+	}
+
+	// 検証を通るテンプレートだけを残す（ローカルテンプレートは FullName 不要）
+	valid := NewTemplateValidator(templates).Valid(templates)
+	require.Len(t, valid, 2)
+	assert.Equal(t, "acme/api", valid[0].FullName)
+	assert.Equal(t, "/templates/skeleton", valid[1].CloneURL)
+}
+
 func TestConfigValidator_ValidateProjectConfig(t *testing.T) {
 	templates := []models.Template{
 		{